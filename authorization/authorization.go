@@ -1,13 +1,40 @@
 package authorization
 
 import (
+	"context"
 	"net/http"
+
+	"github.com/deb-ict/go-router"
 )
 
+const (
+	resultKey router.ContextKey = "router::authorization::result"
+
+	OutcomeAllowed      string = "allowed"
+	OutcomeUnauthorized string = "unauthorized"
+	OutcomeForbidden    string = "forbidden"
+)
+
+type Result struct {
+	Policy  string
+	Outcome string
+	Subject string
+}
+
+func WithResult(ctx context.Context) (context.Context, *Result) {
+	result := &Result{}
+	return context.WithValue(ctx, resultKey, result), result
+}
+
+func GetResult(ctx context.Context) *Result {
+	result, _ := ctx.Value(resultKey).(*Result)
+	return result
+}
+
 func UnauthorizedHandler(w http.ResponseWriter, r *http.Request) {
-	http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	router.Error(w, r, router.NewProblem(http.StatusUnauthorized, ""))
 }
 
 func ForbiddenHandler(w http.ResponseWriter, r *http.Request) {
-	http.Error(w, "Forbidden", http.StatusForbidden)
+	router.Error(w, r, router.NewProblem(http.StatusForbidden, ""))
 }
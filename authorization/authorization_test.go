@@ -5,6 +5,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/deb-ict/go-router"
 )
 
 func Test_UnauthorizedHandler(t *testing.T) {
@@ -38,3 +40,18 @@ func Test_ForbiddenHandler(t *testing.T) {
 		t.Errorf("ForbiddenHandler failed: Invalid body: got %v, expected Forbidden", responseBody)
 	}
 }
+
+func Test_UnauthorizedHandler_ProblemDetails(t *testing.T) {
+	r := router.NewRouter().UseProblemDetails()
+	r.HandleFunc("/orders", UnauthorizedHandler)
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest("GET", "http://testing/orders", nil))
+
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("UnauthorizedHandler failed: Invalid status code: got %v, expected %v", recorder.Code, http.StatusUnauthorized)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != router.ProblemContentType {
+		t.Errorf("UnauthorizedHandler failed: Invalid content type: got %v, expected %v", contentType, router.ProblemContentType)
+	}
+}
@@ -35,24 +35,37 @@ func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
 func (m *Middleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		route := router.CurrentRoute(r)
+		auth := authentication.GetContext(r.Context())
+		result := GetResult(r.Context())
+		if result != nil && auth.IsAuthenticated() {
+			result.Subject = auth.GetSubjectId()
+		}
+
 		if route != nil && route.IsAuthorized() {
-			auth := authentication.GetContext(r.Context())
+			policyName := route.GetAuthorizationPolicy()
+			if result != nil {
+				result.Policy = policyName
+			}
+
 			if !auth.IsAuthenticated() {
+				record(result, OutcomeUnauthorized)
 				m.UnauthorizedHandler.ServeHTTP(w, r)
 				return
 			}
 
-			policyName := route.GetAuthorizationPolicy()
 			policy, ok := m.policies[policyName]
 			if !ok {
+				record(result, OutcomeUnauthorized)
 				m.UnauthorizedHandler.ServeHTTP(w, r)
 				return
 			}
 
 			if !policy.MeetsRequirements(auth) {
+				record(result, OutcomeForbidden)
 				m.ForbiddenHandler.ServeHTTP(w, r)
 				return
 			}
+			record(result, OutcomeAllowed)
 		}
 		next.ServeHTTP(w, r)
 	})
@@ -75,3 +88,9 @@ func (m *Middleware) GetPolicy(name string) (Policy, bool) {
 	policy, ok := m.policies[name]
 	return policy, ok
 }
+
+func record(result *Result, outcome string) {
+	if result != nil {
+		result.Outcome = outcome
+	}
+}
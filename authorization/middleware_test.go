@@ -170,6 +170,47 @@ func Test_Middleware_RequirementFailed(t *testing.T) {
 	}
 }
 
+func Test_Middleware_Result(t *testing.T) {
+	var routeContextKey router.ContextKey = "router::route"
+
+	middleware := NewMiddleware(WithPolicy("admin", NewRoleRequirement("admin")))
+	test := middleware.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	type testCase struct {
+		authenticated bool
+		roles         []string
+		policy        string
+		outcome       string
+		subject       string
+	}
+	tests := []testCase{
+		{true, []string{"admin"}, "admin", OutcomeAllowed, "user-1"},
+		{true, nil, "admin", OutcomeForbidden, "user-1"},
+		{false, nil, "admin", OutcomeUnauthorized, ""},
+		{true, nil, "", "", "user-1"},
+	}
+
+	for _, tc := range tests {
+		claims := make(authentication.ClaimMap)
+		claims.AddClaim(authentication.ClaimSubjectId, "user-1")
+		for _, role := range tc.roles {
+			claims.AddClaim(authentication.ClaimRole, role)
+		}
+		route := &router.Route{}
+		route.Authorize(tc.policy)
+
+		ctx, result := WithResult(context.Background())
+		ctx = context.WithValue(ctx, routeContextKey, route)
+		ctx = authentication.SetContext(ctx, authentication.NewContext(tc.authenticated, claims))
+		req := httptest.NewRequest("GET", "http://testing", nil).WithContext(ctx)
+		test.ServeHTTP(httptest.NewRecorder(), req)
+
+		if result.Policy != tc.policy || result.Outcome != tc.outcome || result.Subject != tc.subject {
+			t.Errorf("Middleware(%s) failed: got %+v, expected %s/%s/%s", tc.outcome, *result, tc.policy, tc.outcome, tc.subject)
+		}
+	}
+}
+
 func Test_Middleware_PolicyNotFound(t *testing.T) {
 	var routeContextKey router.ContextKey = "router::route"
 
@@ -0,0 +1,310 @@
+package binding
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/validation"
+)
+
+const (
+	TagPath   string = "path"
+	TagQuery  string = "query"
+	TagHeader string = "header"
+	TagForm   string = "form"
+
+	BodyField string = "body"
+
+	DefaultMaxMemory int64 = 32 << 20
+)
+
+var (
+	ErrBindingFailed        = errors.New("request binding failed")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrInvalidTarget        = errors.New("binding target must be a pointer to a struct")
+
+	DefaultBinder = NewBinder()
+)
+
+type BinderOption func(*Binder)
+
+type Binder struct {
+	MaxMemory  int64
+	TimeLayout string
+}
+
+type valueSource struct {
+	tag    string
+	lookup func(name string) ([]string, bool)
+}
+
+type Error struct {
+	Context validation.Context
+}
+
+func (e *Error) Error() string {
+	return ErrBindingFailed.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return ErrBindingFailed
+}
+
+func (e *Error) Problem() *router.Problem {
+	return validation.NewProblem(e.Context)
+}
+
+type MediaTypeError struct {
+	MediaType string
+}
+
+func (e *MediaTypeError) Error() string {
+	if e.MediaType == "" {
+		return ErrUnsupportedMediaType.Error()
+	}
+	return ErrUnsupportedMediaType.Error() + ": " + e.MediaType
+}
+
+func (e *MediaTypeError) Unwrap() error {
+	return ErrUnsupportedMediaType
+}
+
+func (e *MediaTypeError) Problem() *router.Problem {
+	return router.NewProblem(http.StatusUnsupportedMediaType, e.Error())
+}
+
+func NewBinder(opts ...BinderOption) *Binder {
+	b := &Binder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.EnsureDefaults()
+
+	return b
+}
+
+func Bind(r *http.Request, v any) error {
+	return DefaultBinder.Bind(r, v)
+}
+
+func (b *Binder) EnsureDefaults() {
+	if b.MaxMemory == 0 {
+		b.MaxMemory = DefaultMaxMemory
+	}
+}
+
+func (b *Binder) Bind(r *http.Request, v any) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	ctx := validation.NewContext()
+
+	// Fields bound from the path, query or headers can not be set through the body
+	restore := saveFields(target.Elem())
+	if err := b.bindBody(r, v, ctx); err != nil {
+		return err
+	}
+	restore()
+
+	params := router.Params(r)
+	query := r.URL.Query()
+	sources := []valueSource{
+		{TagPath, func(name string) ([]string, bool) {
+			value, ok := params[strings.ToLower(name)]
+			return []string{value}, ok
+		}},
+		{TagQuery, func(name string) ([]string, bool) {
+			values, ok := query[name]
+			return values, ok && len(values) > 0
+		}},
+		{TagHeader, func(name string) ([]string, bool) {
+			values := r.Header.Values(name)
+			return values, len(values) > 0
+		}},
+	}
+	if r.Form != nil {
+		sources = append(sources, valueSource{TagForm, func(name string) ([]string, bool) {
+			values, ok := r.Form[name]
+			return values, ok && len(values) > 0
+		}})
+	}
+	if err := b.bindFields(target.Elem(), sources, ctx); err != nil {
+		return err
+	}
+
+	if !ctx.HasErrors() {
+		return nil
+	}
+	requestCtx := validation.GetContext(r.Context())
+	for name, messages := range ctx.GetErrors() {
+		for _, message := range messages {
+			requestCtx.AddError(name, message)
+		}
+	}
+	return &Error{
+		Context: ctx,
+	}
+}
+
+func (b *Binder) bindBody(r *http.Request, v any, ctx validation.Context) error {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return &MediaTypeError{MediaType: contentType}
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		err = json.NewDecoder(r.Body).Decode(v)
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		err = xml.NewDecoder(r.Body).Decode(v)
+	case mediaType == "application/x-www-form-urlencoded":
+		err = r.ParseForm()
+	case mediaType == "multipart/form-data":
+		err = r.ParseMultipartForm(b.MaxMemory)
+	default:
+		return &MediaTypeError{MediaType: mediaType}
+	}
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	var typeError *json.UnmarshalTypeError
+	var maxBytesError *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesError):
+		return err
+	case errors.As(err, &typeError) && typeError.Field != "":
+		ctx.AddError(typeError.Field, "must be of type "+typeError.Type.String())
+	default:
+		ctx.AddError(BodyField, "is malformed")
+	}
+	return nil
+}
+
+func (b *Binder) bindFields(v reflect.Value, sources []valueSource, ctx validation.Context) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := b.bindFields(value, sources, ctx); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		for _, source := range sources {
+			name, opts, ok := getTag(field, source.tag)
+			if !ok {
+				continue
+			}
+			if source.tag == TagHeader {
+				name = textproto.CanonicalMIMEHeaderKey(name)
+			}
+
+			values, found := source.lookup(name)
+			if !found {
+				defaultValue, ok := field.Tag.Lookup("default")
+				if !ok {
+					continue
+				}
+				values = []string{defaultValue}
+			}
+			if hasOption(opts, "csv") {
+				values = splitValues(values)
+			}
+			if len(values) == 0 {
+				continue
+			}
+
+			layout := field.Tag.Get("layout")
+			if layout == "" {
+				layout = b.TimeLayout
+			}
+			if err := setValues(value, values, layout); err != nil {
+				var conversionErr *ConversionError
+				if !errors.As(err, &conversionErr) {
+					return err
+				}
+				ctx.AddError(name, conversionErr.Message)
+			}
+		}
+	}
+	return nil
+}
+
+func saveFields(v reflect.Value) func() {
+	fields := make([]reflect.Value, 0)
+	saved := make([]reflect.Value, 0)
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				walk(v.Field(i))
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			for _, tag := range []string{TagPath, TagQuery, TagHeader} {
+				if _, _, ok := getTag(field, tag); ok {
+					value := reflect.New(field.Type).Elem()
+					value.Set(v.Field(i))
+					fields = append(fields, v.Field(i))
+					saved = append(saved, value)
+					break
+				}
+			}
+		}
+	}
+	walk(v)
+
+	return func() {
+		for i, field := range fields {
+			field.Set(saved[i])
+		}
+	}
+}
+
+func getTag(field reflect.StructField, tag string) (string, string, bool) {
+	value, ok := field.Tag.Lookup(tag)
+	if !ok || value == "-" {
+		return "", "", false
+	}
+	name, opts, _ := strings.Cut(value, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, opts, true
+}
+
+func hasOption(opts string, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
+	}
+	return false
+}
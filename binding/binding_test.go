@@ -0,0 +1,267 @@
+package binding
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/validation"
+)
+
+type bindingPaging struct {
+	Page int `query:"page" default:"1"`
+	Size int `query:"size" default:"20"`
+}
+
+type bindingRequest struct {
+	bindingPaging
+	Id      int64     `path:"id" json:"-"`
+	Tenant  string    `header:"x-tenant" json:"-"`
+	Expand  []string  `query:"expand,csv" json:"-"`
+	Since   time.Time `query:"since" layout:"2006-01-02" json:"-"`
+	Active  *bool     `query:"active" json:"-"`
+	Name    string    `json:"name" xml:"name" form:"name"`
+	Count   int       `json:"count" xml:"count" form:"count"`
+	private string    `query:"private"`
+}
+
+func serveBinding(req *http.Request, binder *Binder) (*bindingRequest, error) {
+	var result *bindingRequest
+	var err error
+	r := router.NewRouter()
+	r.HandleFunc("/orders/{id}", func(w http.ResponseWriter, req *http.Request) {
+		result = &bindingRequest{}
+		err = binder.Bind(req, result)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	return result, err
+}
+
+func Test_NewBinder(t *testing.T) {
+	b := NewBinder()
+	if b.MaxMemory != DefaultMaxMemory {
+		t.Errorf("NewBinder() failed: got max memory %v, expected %v", b.MaxMemory, DefaultMaxMemory)
+	}
+}
+
+func Test_Binder_Bind(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders/42?page=3&expand=lines,customer&since=2024-01-02&active=false&private=x", strings.NewReader(`{"name":"test","count":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", "acme")
+
+	result, err := serveBinding(req, NewBinder())
+	if err != nil {
+		t.Fatalf("Bind() failed: %v", err)
+	}
+	if result.Id != 42 || result.Tenant != "acme" || result.Page != 3 || result.Size != 20 {
+		t.Errorf("Bind() failed: got %+v", result)
+	}
+	if len(result.Expand) != 2 || result.Expand[1] != "customer" {
+		t.Errorf("Bind() failed: got expand %v, expected [lines customer]", result.Expand)
+	}
+	if !result.Since.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Bind() failed: got since %v", result.Since)
+	}
+	if result.Active == nil || *result.Active {
+		t.Errorf("Bind() failed: got active %v, expected false", result.Active)
+	}
+	if result.Name != "test" || result.Count != 5 {
+		t.Errorf("Bind() failed: got body %s/%v, expected test/5", result.Name, result.Count)
+	}
+	if result.private != "" {
+		t.Error("Bind() failed: unexported field bound")
+	}
+}
+
+func Test_Binder_Bind_PathCase(t *testing.T) {
+	var result struct {
+		OrderId int64 `path:"orderId"`
+		ID      int64 `path:""`
+	}
+	var err error
+	r := router.NewRouter()
+	r.HandleFunc("/customers/{ID}/orders/{orderId}", func(w http.ResponseWriter, req *http.Request) {
+		err = Bind(req, &result)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/customers/3/orders/7", nil))
+
+	if err != nil {
+		t.Fatalf("Bind() failed: %v", err)
+	}
+	if result.OrderId != 7 || result.ID != 3 {
+		t.Errorf("Bind() failed: got %+v, expected order 7 and id 3", result)
+	}
+}
+
+func Test_Binder_Bind_Xml(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders/1", strings.NewReader(`<order><name>xml</name><count>2</count></order>`))
+	req.Header.Set("Content-Type", "application/xml")
+
+	result, err := serveBinding(req, NewBinder())
+	if err != nil {
+		t.Fatalf("Bind() failed: %v", err)
+	}
+	if result.Name != "xml" || result.Count != 2 {
+		t.Errorf("Bind() failed: got %s/%v, expected xml/2", result.Name, result.Count)
+	}
+}
+
+func Test_Binder_Bind_Form(t *testing.T) {
+	form := url.Values{"name": {"form"}, "count": {"3"}}
+	req := httptest.NewRequest(http.MethodPost, "/orders/1", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	result, err := serveBinding(req, NewBinder())
+	if err != nil {
+		t.Fatalf("Bind() failed: %v", err)
+	}
+	if result.Name != "form" || result.Count != 3 {
+		t.Errorf("Bind() failed: got %s/%v, expected form/3", result.Name, result.Count)
+	}
+}
+
+func Test_Binder_Bind_Multipart(t *testing.T) {
+	var body strings.Builder
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("name", "multipart")
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/1", strings.NewReader(body.String()))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	result, err := serveBinding(req, NewBinder())
+	if err != nil {
+		t.Fatalf("Bind() failed: %v", err)
+	}
+	if result.Name != "multipart" {
+		t.Errorf("Bind() failed: got %s, expected multipart", result.Name)
+	}
+}
+
+func Test_Binder_Bind_ConversionErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders/abc?page=x&since=today", strings.NewReader(`{"name":"test","count":"five"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	requestCtx := validation.NewContext()
+	req = req.WithContext(validation.SetContext(req.Context(), requestCtx))
+
+	_, err := serveBinding(req, NewBinder())
+	if !errors.Is(err, ErrBindingFailed) {
+		t.Fatalf("Bind() failed: got %v, expected %v", err, ErrBindingFailed)
+	}
+	var bindingErr *Error
+	if !errors.As(err, &bindingErr) {
+		t.Fatalf("Bind() failed: got %T, expected *Error", err)
+	}
+
+	errs := bindingErr.Context.GetErrors()
+	for _, name := range []string{"id", "page", "since", "count"} {
+		if len(errs[name]) != 1 {
+			t.Errorf("Bind() failed: got errors %v for %s, expected 1", errs[name], name)
+		}
+	}
+	if len(requestCtx.GetErrors()) != len(errs) {
+		t.Errorf("Bind() failed: got %v request errors, expected %v", len(requestCtx.GetErrors()), len(errs))
+	}
+}
+
+func Test_Binder_Bind_MalformedBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders/1", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err := serveBinding(req, NewBinder())
+	var bindingErr *Error
+	if !errors.As(err, &bindingErr) || len(bindingErr.Context.GetErrors()[BodyField]) != 1 {
+		t.Errorf("Bind() failed: got %v, expected body error", err)
+	}
+}
+
+func Test_Binder_Bind_UnsupportedMediaType(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders/1", strings.NewReader("data"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	_, err := serveBinding(req, NewBinder())
+	if !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("Bind() failed: got %v, expected %v", err, ErrUnsupportedMediaType)
+	}
+	var problemErr router.ProblemError
+	if !errors.As(err, &problemErr) || problemErr.Problem().Status != http.StatusUnsupportedMediaType {
+		t.Errorf("Bind() failed: got %v, expected %v problem", err, http.StatusUnsupportedMediaType)
+	}
+}
+
+func Test_Binder_Bind_MalformedForm(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+	}{
+		{"application/x-www-form-urlencoded", "name=%zz"},
+		{"multipart/form-data", "not multipart"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/orders/1", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", tt.contentType)
+
+		_, err := serveBinding(req, NewBinder())
+		var bindingErr *Error
+		if !errors.As(err, &bindingErr) || len(bindingErr.Context.GetErrors()[BodyField]) != 1 {
+			t.Errorf("Bind(%s) failed: got %v, expected body error", tt.contentType, err)
+		}
+	}
+}
+
+func Test_Bind_InvalidTarget(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	tests := []any{nil, bindingRequest{}, new(int)}
+	for _, target := range tests {
+		if err := Bind(req, target); !errors.Is(err, ErrInvalidTarget) {
+			t.Errorf("Bind(%T) failed: got %v, expected %v", target, err, ErrInvalidTarget)
+		}
+	}
+}
+
+func Test_Error_Problem(t *testing.T) {
+	ctx := validation.NewContext()
+	ctx.AddError("page", "must be an integer")
+
+	problem := (&Error{Context: ctx}).Problem()
+	if problem.Status != http.StatusBadRequest {
+		t.Errorf("Error.Problem() failed: got status %v, expected %v", problem.Status, http.StatusBadRequest)
+	}
+}
+
+func Test_Binder_Bind_BodyOverride(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders/42", strings.NewReader(`{"Page":99,"Id":7,"Tenant":"evil","Role":"admin","name":"test"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", "acme")
+
+	var result struct {
+		bindingPaging
+		Id     int64  `path:"id"`
+		Tenant string `header:"x-tenant"`
+		Role   string `query:"role"`
+		Name   string `json:"name"`
+	}
+	var err error
+	r := router.NewRouter()
+	r.HandleFunc("/orders/{id}", func(w http.ResponseWriter, req *http.Request) {
+		err = Bind(req, &result)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if err != nil {
+		t.Fatalf("Bind() failed: %v", err)
+	}
+	if result.Id != 42 || result.Tenant != "acme" || result.Role != "" || result.Page != 1 {
+		t.Errorf("Bind() failed: got %+v, expected the body not to set path, query and header fields", result)
+	}
+	if result.Name != "test" {
+		t.Errorf("Bind() failed: got name %s, expected test", result.Name)
+	}
+}
@@ -0,0 +1,129 @@
+package binding
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_setValues(t *testing.T) {
+	var s struct {
+		String   string
+		Bool     bool
+		Int      int
+		Int8     int8
+		Uint     uint16
+		Float    float64
+		Time     time.Time
+		Duration time.Duration
+		Addr     netip.Addr
+		Pointer  *int
+		Slice    []int
+		Bytes    []byte
+	}
+	v := reflect.ValueOf(&s).Elem()
+
+	tests := []struct {
+		field  string
+		values []string
+		layout string
+	}{
+		{"String", []string{"text"}, ""},
+		{"Bool", []string{"true"}, ""},
+		{"Int", []string{"-42"}, ""},
+		{"Int8", []string{"8"}, ""},
+		{"Uint", []string{"16"}, ""},
+		{"Float", []string{"1.5"}, ""},
+		{"Time", []string{"2024-02-03"}, "2006-01-02"},
+		{"Duration", []string{"1m30s"}, ""},
+		{"Addr", []string{"10.0.0.1"}, ""},
+		{"Pointer", []string{"7"}, ""},
+		{"Slice", []string{"1", "2", "3"}, ""},
+		{"Bytes", []string{"raw"}, ""},
+	}
+	for _, tt := range tests {
+		if err := setValues(v.FieldByName(tt.field), tt.values, tt.layout); err != nil {
+			t.Errorf("setValues(%s) failed: %v", tt.field, err)
+		}
+	}
+
+	if s.String != "text" || !s.Bool || s.Int != -42 || s.Int8 != 8 || s.Uint != 16 || s.Float != 1.5 {
+		t.Errorf("setValues() failed: got %+v", s)
+	}
+	if !s.Time.Equal(time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("setValues(Time) failed: got %v", s.Time)
+	}
+	if s.Duration != 90*time.Second {
+		t.Errorf("setValues(Duration) failed: got %v", s.Duration)
+	}
+	if s.Addr != netip.MustParseAddr("10.0.0.1") {
+		t.Errorf("setValues(Addr) failed: got %v", s.Addr)
+	}
+	if s.Pointer == nil || *s.Pointer != 7 {
+		t.Errorf("setValues(Pointer) failed: got %v", s.Pointer)
+	}
+	if len(s.Slice) != 3 || s.Slice[2] != 3 {
+		t.Errorf("setValues(Slice) failed: got %v", s.Slice)
+	}
+	if string(s.Bytes) != "raw" {
+		t.Errorf("setValues(Bytes) failed: got %s", s.Bytes)
+	}
+}
+
+func Test_setValues_Invalid(t *testing.T) {
+	var s struct {
+		Bool     bool
+		Int      int
+		Int8     int8
+		Uint     uint
+		Float    float32
+		Time     time.Time
+		Duration time.Duration
+		Addr     netip.Addr
+		Map      map[string]string
+	}
+	v := reflect.ValueOf(&s).Elem()
+
+	tests := []struct {
+		field   string
+		value   string
+		message string
+	}{
+		{"Bool", "maybe", "must be a boolean"},
+		{"Int", "abc", "must be an integer"},
+		{"Int8", "300", "is out of range"},
+		{"Uint", "-1", "must be a positive integer"},
+		{"Float", "x", "must be a number"},
+		{"Time", "yesterday", "must be a valid time"},
+		{"Duration", "long", "must be a valid duration"},
+		{"Addr", "host", "invalid value"},
+	}
+	for _, tt := range tests {
+		err := setValues(v.FieldByName(tt.field), []string{tt.value}, "")
+		var conversionErr *ConversionError
+		if !errors.As(err, &conversionErr) {
+			t.Errorf("setValues(%s) failed: got %v, expected conversion error", tt.field, err)
+			continue
+		}
+		if conversionErr.Message != tt.message {
+			t.Errorf("setValues(%s) failed: got %s, expected %s", tt.field, conversionErr.Message, tt.message)
+		}
+		if !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("setValues(%s) failed: error does not wrap ErrInvalidValue", tt.field)
+		}
+	}
+
+	if err := setValues(v.FieldByName("Map"), []string{"x"}, ""); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("setValues(Map) failed: got %v, expected %v", err, ErrUnsupportedType)
+	}
+}
+
+func Test_splitValues(t *testing.T) {
+	result := splitValues([]string{"a, b", "c,,", " d "})
+	expected := []string{"a", "b", "c", "d"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("splitValues() failed: got %v, expected %v", result, expected)
+	}
+}
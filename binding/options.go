@@ -0,0 +1,13 @@
+package binding
+
+func WithMaxMemory(maxMemory int64) BinderOption {
+	return func(b *Binder) {
+		b.MaxMemory = maxMemory
+	}
+}
+
+func WithTimeLayout(layout string) BinderOption {
+	return func(b *Binder) {
+		b.TimeLayout = layout
+	}
+}
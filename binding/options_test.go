@@ -0,0 +1,27 @@
+package binding
+
+import (
+	"testing"
+)
+
+func Test_WithMaxMemory(t *testing.T) {
+	expected := int64(1024)
+	b := &Binder{}
+	option := WithMaxMemory(expected)
+	option(b)
+
+	if b.MaxMemory != expected {
+		t.Errorf("WithMaxMemory() failed: got %v, expected %v", b.MaxMemory, expected)
+	}
+}
+
+func Test_WithTimeLayout(t *testing.T) {
+	expected := "2006-01-02"
+	b := &Binder{}
+	option := WithTimeLayout(expected)
+	option(b)
+
+	if b.TimeLayout != expected {
+		t.Errorf("WithTimeLayout() failed: got %s, expected %s", b.TimeLayout, expected)
+	}
+}
@@ -0,0 +1,55 @@
+package bodylimit
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::bodylimit"
+
+	DefaultMessage string = "Request Entity Too Large"
+)
+
+func RouteLimit(limit int64) router.RouteOption {
+	return func(r *router.Route) {
+		r.SetMetadata(metadataKey, limit)
+	}
+}
+
+func Disabled() router.RouteOption {
+	return RouteLimit(0)
+}
+
+func GetRouteLimit(route *router.Route) (int64, bool) {
+	if route == nil {
+		return 0, false
+	}
+	value, ok := route.GetMetadata(metadataKey)
+	if !ok {
+		return 0, false
+	}
+	limit, ok := value.(int64)
+	return limit, ok
+}
+
+func IsLimitExceeded(err error) bool {
+	var maxBytesError *http.MaxBytesError
+	return errors.As(err, &maxBytesError)
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if IsLimitExceeded(err) {
+		b.exceeded = true
+	}
+	return n, err
+}
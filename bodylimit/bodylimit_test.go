@@ -0,0 +1,45 @@
+package bodylimit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_RouteLimit(t *testing.T) {
+	route := &router.Route{}
+	option := RouteLimit(1024)
+	option(route)
+
+	limit, ok := GetRouteLimit(route)
+	if !ok || limit != 1024 {
+		t.Errorf("RouteLimit() failed: got %v, expected 1024", limit)
+	}
+	if _, ok := GetRouteLimit(nil); ok {
+		t.Error("GetRouteLimit(nil) failed: got true, expected false")
+	}
+}
+
+func Test_Disabled(t *testing.T) {
+	route := &router.Route{}
+	option := Disabled()
+	option(route)
+
+	limit, ok := GetRouteLimit(route)
+	if !ok || limit != 0 {
+		t.Errorf("Disabled() failed: got %v, expected 0", limit)
+	}
+}
+
+func Test_IsLimitExceeded(t *testing.T) {
+	err := fmt.Errorf("decode: %w", &http.MaxBytesError{Limit: 10})
+	if !IsLimitExceeded(err) {
+		t.Error("IsLimitExceeded(MaxBytesError) failed: got false, expected true")
+	}
+	if IsLimitExceeded(errors.New("other")) {
+		t.Error("IsLimitExceeded(other) failed: got true, expected false")
+	}
+}
@@ -0,0 +1,72 @@
+package bodylimit
+
+import (
+	"net/http"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	Limit                int64
+	Message              string
+	LimitExceededHandler http.Handler
+}
+
+func NewMiddleware(limit int64, opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{
+		Limit: limit,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, limit int64, opts ...MiddlewareOption) {
+	m := NewMiddleware(limit, opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := m.Limit
+		if routeLimit, ok := GetRouteLimit(router.CurrentRoute(r)); ok {
+			limit = routeLimit
+		}
+		if limit <= 0 || r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if r.ContentLength > limit {
+			m.LimitExceededHandler.ServeHTTP(w, r)
+			return
+		}
+
+		rw := router.NewResponseWriter(w)
+		body := &limitedBody{ReadCloser: http.MaxBytesReader(rw, r.Body, limit)}
+		r.Body = body
+		next.ServeHTTP(rw, r)
+
+		if body.exceeded && !rw.HeaderWritten() && !rw.Hijacked() {
+			m.LimitExceededHandler.ServeHTTP(rw, r)
+		}
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.Message == "" {
+		m.Message = DefaultMessage
+	}
+	if m.LimitExceededHandler == nil {
+		m.LimitExceededHandler = http.HandlerFunc(m.serveLimitExceeded)
+	}
+}
+
+func (m *Middleware) serveLimitExceeded(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Connection", "close")
+	router.Error(w, r, router.NewProblem(http.StatusRequestEntityTooLarge, m.Message))
+}
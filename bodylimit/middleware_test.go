@@ -0,0 +1,116 @@
+package bodylimit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(1024, o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.Limit != 1024 {
+			t.Errorf("NewMiddleware() failed: got limit %v, expected 1024", m.Limit)
+		}
+		if m.LimitExceededHandler == nil {
+			t.Error("NewMiddleware() failed: Default handler not set")
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router, 1024)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware(t *testing.T) {
+	handlerCalled := 0
+	readAll := func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled++
+		if _, err := io.ReadAll(r.Body); err != nil {
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	r := router.NewRouter()
+	UseMiddleware(r, 8)
+	r.HandleFunc("/small", readAll)
+	r.HandleFunc("/upload", readAll, RouteLimit(64))
+	r.HandleFunc("/unlimited", readAll, Disabled())
+
+	type testCase struct {
+		path          string
+		body          string
+		contentLength bool
+		expected      int
+		handlerCalled int
+	}
+	tests := []testCase{
+		{"/small", "1234", true, http.StatusCreated, 1},
+		{"/small", "123456789", true, http.StatusRequestEntityTooLarge, 0},
+		{"/small", "123456789", false, http.StatusRequestEntityTooLarge, 1},
+		{"/upload", strings.Repeat("a", 64), true, http.StatusCreated, 1},
+		{"/upload", strings.Repeat("a", 65), true, http.StatusRequestEntityTooLarge, 0},
+		{"/unlimited", strings.Repeat("a", 1024), true, http.StatusCreated, 1},
+	}
+
+	for _, tc := range tests {
+		handlerCalled = 0
+		req := httptest.NewRequest(http.MethodPost, tc.path, io.NopCloser(strings.NewReader(tc.body)))
+		if tc.contentLength {
+			req.ContentLength = int64(len(tc.body))
+		} else {
+			req.ContentLength = -1
+		}
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if rsp.Code != tc.expected {
+			t.Errorf("Middleware(%s, %d bytes) failed: got status %v, expected %v", tc.path, len(tc.body), rsp.Code, tc.expected)
+		}
+		if handlerCalled != tc.handlerCalled {
+			t.Errorf("Middleware(%s, %d bytes) failed: handler called %v times, expected %v", tc.path, len(tc.body), handlerCalled, tc.handlerCalled)
+		}
+	}
+}
+
+func Test_Middleware_HandlerResponse(t *testing.T) {
+	m := NewMiddleware(4)
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); IsLimitExceeded(err) {
+			http.Error(w, "custom", http.StatusBadRequest)
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader("123456")))
+	req.ContentLength = -1
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusBadRequest {
+		t.Errorf("Middleware(handlerResponse) failed: got status %v, expected %v", rsp.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,23 @@
+package bodylimit
+
+import (
+	"net/http"
+)
+
+func WithMessage(message string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Message = message
+	}
+}
+
+func WithLimitExceededHandler(handler http.Handler) MiddlewareOption {
+	return func(m *Middleware) {
+		m.LimitExceededHandler = handler
+	}
+}
+
+func WithLimitExceededHandlerFunc(handle func(http.ResponseWriter, *http.Request)) MiddlewareOption {
+	return func(m *Middleware) {
+		m.LimitExceededHandler = http.HandlerFunc(handle)
+	}
+}
@@ -0,0 +1,37 @@
+package bodylimit
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_WithMessage(t *testing.T) {
+	expected := "test"
+	m := &Middleware{}
+	option := WithMessage(expected)
+	option(m)
+
+	if m.Message != expected {
+		t.Errorf("WithMessage() failed: got %s, expected %s", m.Message, expected)
+	}
+}
+
+func Test_WithLimitExceededHandler(t *testing.T) {
+	m := &Middleware{}
+	option := WithLimitExceededHandler(http.NotFoundHandler())
+	option(m)
+
+	if m.LimitExceededHandler == nil {
+		t.Error("WithLimitExceededHandler() failed: handler not set")
+	}
+}
+
+func Test_WithLimitExceededHandlerFunc(t *testing.T) {
+	m := &Middleware{}
+	option := WithLimitExceededHandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	option(m)
+
+	if m.LimitExceededHandler == nil {
+		t.Error("WithLimitExceededHandlerFunc() failed: handler not set")
+	}
+}
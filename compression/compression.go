@@ -0,0 +1,117 @@
+package compression
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::compression"
+
+	EncodingGzip    string = "gzip"
+	EncodingDeflate string = "deflate"
+
+	DefaultMinLength int = 1024
+)
+
+var DefaultExcludedContentTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"font/woff2",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/x-bzip2",
+	"application/zstd",
+	"application/wasm",
+}
+
+func Disabled() router.RouteOption {
+	return func(r *router.Route) {
+		r.SetMetadata(metadataKey, false)
+	}
+}
+
+func IsRouteDisabled(route *router.Route) bool {
+	if route == nil {
+		return false
+	}
+	value, ok := route.GetMetadata(metadataKey)
+	if !ok {
+		return false
+	}
+	enabled, ok := value.(bool)
+	return ok && !enabled
+}
+
+func NegotiateEncoding(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "q") {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+		if coding == "*" {
+			wildcard = q
+			continue
+		}
+		qualities[coding] = q
+	}
+
+	best := ""
+	bestQuality := 0.0
+	for _, encoding := range supported {
+		q, ok := qualities[encoding]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQuality {
+			best = encoding
+			bestQuality = q
+		}
+	}
+	return best
+}
+
+func isExcludedContentType(contentType string, excluded []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	if mediaType == "image/svg+xml" {
+		return false
+	}
+	for _, e := range excluded {
+		if strings.HasSuffix(e, "/") && strings.HasPrefix(mediaType, e) {
+			return true
+		}
+		if mediaType == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package compression
+
+import (
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_NegotiateEncoding(t *testing.T) {
+	type testCase struct {
+		acceptEncoding string
+		expected       string
+	}
+	tests := []testCase{
+		{"", ""},
+		{"gzip", EncodingGzip},
+		{"deflate", EncodingDeflate},
+		{"gzip, deflate", EncodingGzip},
+		{"deflate, gzip", EncodingGzip},
+		{"gzip;q=0.5, deflate", EncodingDeflate},
+		{"gzip;q=0, deflate;q=0", ""},
+		{"br", ""},
+		{"*", EncodingGzip},
+		{"*;q=0.1, gzip;q=0", EncodingDeflate},
+		{"identity", ""},
+		{"GZIP;Q=0.8", EncodingGzip},
+		{"gzip;q=invalid", ""},
+	}
+
+	supported := []string{EncodingGzip, EncodingDeflate}
+	for _, tc := range tests {
+		result := NegotiateEncoding(tc.acceptEncoding, supported)
+		if result != tc.expected {
+			t.Errorf("NegotiateEncoding(%s) failed: got %v, expected %v", tc.acceptEncoding, result, tc.expected)
+		}
+	}
+}
+
+func Test_isExcludedContentType(t *testing.T) {
+	type testCase struct {
+		contentType string
+		expected    bool
+	}
+	tests := []testCase{
+		{"text/html; charset=utf-8", false},
+		{"application/json", false},
+		{"image/png", true},
+		{"image/svg+xml", false},
+		{"video/mp4", true},
+		{"application/zip", true},
+		{"", false},
+	}
+
+	for _, tc := range tests {
+		result := isExcludedContentType(tc.contentType, DefaultExcludedContentTypes)
+		if result != tc.expected {
+			t.Errorf("isExcludedContentType(%s) failed: got %v, expected %v", tc.contentType, result, tc.expected)
+		}
+	}
+}
+
+func Test_Disabled(t *testing.T) {
+	route := &router.Route{}
+	if IsRouteDisabled(route) {
+		t.Error("IsRouteDisabled(default) failed: got true, expected false")
+	}
+
+	option := Disabled()
+	option(route)
+	if !IsRouteDisabled(route) {
+		t.Error("Disabled() failed: route not disabled")
+	}
+}
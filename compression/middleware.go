@@ -0,0 +1,116 @@
+package compression
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	Level                int
+	MinLength            int
+	Encodings            []string
+	ExcludedContentTypes []string
+	gzipPool             sync.Pool
+	zlibPool             sync.Pool
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{
+		Level: gzip.DefaultCompression,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsRouteDisabled(router.CurrentRoute(r)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := NegotiateEncoding(r.Header.Get("Accept-Encoding"), m.Encodings)
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw, rw := newCompressWriter(w, m, encoding)
+		defer func() {
+			if p := recover(); p != nil {
+				cw.abort()
+				panic(p)
+			}
+			cw.Close()
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.MinLength <= 0 {
+		m.MinLength = DefaultMinLength
+	}
+	m.Encodings = slices.DeleteFunc(m.Encodings, func(encoding string) bool {
+		return encoding != EncodingGzip && encoding != EncodingDeflate
+	})
+	if len(m.Encodings) == 0 {
+		m.Encodings = []string{EncodingGzip, EncodingDeflate}
+	}
+	if m.ExcludedContentTypes == nil {
+		m.ExcludedContentTypes = DefaultExcludedContentTypes
+	}
+}
+
+func (m *Middleware) acquireEncoder(encoding string, w io.Writer) io.WriteCloser {
+	switch encoding {
+	case EncodingGzip:
+		if encoder, ok := m.gzipPool.Get().(*gzip.Writer); ok {
+			encoder.Reset(w)
+			return encoder
+		}
+		encoder, err := gzip.NewWriterLevel(w, m.Level)
+		if err != nil {
+			encoder = gzip.NewWriter(w)
+		}
+		return encoder
+	case EncodingDeflate:
+		if encoder, ok := m.zlibPool.Get().(*zlib.Writer); ok {
+			encoder.Reset(w)
+			return encoder
+		}
+		encoder, err := zlib.NewWriterLevel(w, m.Level)
+		if err != nil {
+			encoder = zlib.NewWriter(w)
+		}
+		return encoder
+	}
+	return nil
+}
+
+func (m *Middleware) releaseEncoder(encoding string, encoder io.WriteCloser) {
+	switch encoding {
+	case EncodingGzip:
+		m.gzipPool.Put(encoder)
+	case EncodingDeflate:
+		m.zlibPool.Put(encoder)
+	}
+}
@@ -0,0 +1,248 @@
+package compression
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o, WithEncodings("br", EncodingDeflate))
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.MinLength != DefaultMinLength {
+			t.Errorf("NewMiddleware() failed: got min length %v, expected %v", m.MinLength, DefaultMinLength)
+		}
+		if len(m.Encodings) != 1 || m.Encodings[0] != EncodingDeflate {
+			t.Errorf("NewMiddleware() failed: got encodings %v, expected [deflate]", m.Encodings)
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware(t *testing.T) {
+	body := strings.Repeat("hello world ", 200)
+	type testCase struct {
+		acceptEncoding string
+		contentType    string
+		body           string
+		expected       string
+	}
+	tests := []testCase{
+		{"gzip", "text/plain", body, EncodingGzip},
+		{"deflate", "text/plain", body, EncodingDeflate},
+		{"", "text/plain", body, ""},
+		{"gzip", "text/plain", "small", ""},
+		{"gzip", "image/png", body, ""},
+		{"gzip", "", body, EncodingGzip},
+	}
+
+	for _, tc := range tests {
+		m := NewMiddleware()
+		test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tc.contentType != "" {
+				w.Header().Set("Content-Type", tc.contentType)
+			}
+			w.Write([]byte(tc.body))
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		rsp := httptest.NewRecorder()
+		test.ServeHTTP(rsp, req)
+
+		encoding := rsp.Header().Get("Content-Encoding")
+		if encoding != tc.expected {
+			t.Errorf("Middleware(%s, %s) failed: got encoding %v, expected %v", tc.acceptEncoding, tc.contentType, encoding, tc.expected)
+		}
+		if rsp.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("Middleware(%s, %s) failed: Vary header not set", tc.acceptEncoding, tc.contentType)
+		}
+		if result := decode(t, encoding, rsp.Body); result != tc.body {
+			t.Errorf("Middleware(%s, %s) failed: body mismatch", tc.acceptEncoding, tc.contentType)
+		}
+	}
+}
+
+func Test_Middleware_Status(t *testing.T) {
+	m := NewMiddleware(WithMinLength(1))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusCreated {
+		t.Errorf("Middleware(status) failed: got %v, expected %v", rsp.Code, http.StatusCreated)
+	}
+	if result := decode(t, EncodingGzip, rsp.Body); result != "created" {
+		t.Errorf("Middleware(status) failed: got body %v, expected created", result)
+	}
+}
+
+func Test_Middleware_Flush(t *testing.T) {
+	m := NewMiddleware()
+	flushed := ""
+	var rsp *httptest.ResponseRecorder
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: 1\n\n"))
+		w.(http.Flusher).Flush()
+		flushed = rsp.Body.String()
+		w.Write([]byte("data: 2\n\n"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rsp = httptest.NewRecorder()
+	test.ServeHTTP(rsp, req)
+
+	if rsp.Header().Get("Content-Encoding") != EncodingGzip {
+		t.Error("Middleware(flush) failed: streaming response not compressed")
+	}
+	if len(flushed) == 0 {
+		t.Error("Middleware(flush) failed: data not flushed to client")
+	}
+	if result := decode(t, EncodingGzip, rsp.Body); result != "data: 1\n\ndata: 2\n\n" {
+		t.Errorf("Middleware(flush) failed: got body %q", result)
+	}
+}
+
+func Test_Middleware_Interfaces(t *testing.T) {
+	m := NewMiddleware()
+	exposed := false
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, exposed = w.(http.Flusher)
+		if _, ok := w.(http.Hijacker); ok {
+			t.Error("Middleware(interfaces) failed: hijacker exposed for a writer without it")
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	test.ServeHTTP(httptest.NewRecorder(), req)
+	if !exposed {
+		t.Error("Middleware(interfaces) failed: flusher not exposed")
+	}
+	test.ServeHTTP(struct{ http.ResponseWriter }{httptest.NewRecorder()}, req)
+	if exposed {
+		t.Error("Middleware(interfaces) failed: flusher exposed for a writer without it")
+	}
+}
+
+func Test_Middleware_Panic(t *testing.T) {
+	m := NewMiddleware(WithMinLength(1024))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("partial"))
+		panic("failed")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rsp := httptest.NewRecorder()
+	func() {
+		defer func() {
+			if p := recover(); p != "failed" {
+				t.Errorf("Middleware(panic) failed: got panic %v, expected %v", p, "failed")
+			}
+		}()
+		test.ServeHTTP(rsp, req)
+	}()
+
+	if rsp.Body.Len() != 0 || rsp.Header().Get("Content-Encoding") != "" {
+		t.Errorf("Middleware(panic) failed: got body %q, expected buffered response to be discarded", rsp.Body.String())
+	}
+}
+
+func Test_Middleware_RouteDisabled(t *testing.T) {
+	r := router.NewRouter()
+	UseMiddleware(r, WithMinLength(1))
+	r.HandleFunc("/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("raw"))
+	}, Disabled())
+
+	req := httptest.NewRequest(http.MethodGet, "/download", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, req)
+
+	if rsp.Header().Get("Content-Encoding") != "" {
+		t.Error("Middleware(disabled) failed: response compressed")
+	}
+	if rsp.Body.String() != "raw" {
+		t.Errorf("Middleware(disabled) failed: got body %v, expected raw", rsp.Body.String())
+	}
+}
+
+func Test_Middleware_AlreadyEncoded(t *testing.T) {
+	m := NewMiddleware(WithMinLength(1))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "br")
+		w.Write([]byte("encoded"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, req)
+
+	if rsp.Header().Get("Content-Encoding") != "br" || rsp.Body.String() != "encoded" {
+		t.Error("Middleware(alreadyEncoded) failed: response encoded twice")
+	}
+}
+
+func decode(t *testing.T, encoding string, body io.Reader) string {
+	var reader io.Reader
+	switch encoding {
+	case EncodingGzip:
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			t.Fatalf("decode() failed: %v", err)
+		}
+		reader = gz
+	case EncodingDeflate:
+		zr, err := zlib.NewReader(body)
+		if err != nil {
+			t.Fatalf("decode() failed: %v", err)
+		}
+		reader = zr
+	default:
+		reader = body
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("decode() failed: %v", err)
+	}
+	return string(data)
+}
@@ -0,0 +1,25 @@
+package compression
+
+func WithLevel(level int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Level = level
+	}
+}
+
+func WithMinLength(length int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.MinLength = length
+	}
+}
+
+func WithEncodings(encodings ...string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Encodings = encodings
+	}
+}
+
+func WithExcludedContentTypes(contentTypes ...string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.ExcludedContentTypes = contentTypes
+	}
+}
@@ -0,0 +1,46 @@
+package compression
+
+import (
+	"compress/gzip"
+	"testing"
+)
+
+func Test_WithLevel(t *testing.T) {
+	m := &Middleware{}
+	option := WithLevel(gzip.BestSpeed)
+	option(m)
+
+	if m.Level != gzip.BestSpeed {
+		t.Errorf("WithLevel() failed: got %v, expected %v", m.Level, gzip.BestSpeed)
+	}
+}
+
+func Test_WithMinLength(t *testing.T) {
+	m := &Middleware{}
+	option := WithMinLength(10)
+	option(m)
+
+	if m.MinLength != 10 {
+		t.Errorf("WithMinLength() failed: got %v, expected 10", m.MinLength)
+	}
+}
+
+func Test_WithEncodings(t *testing.T) {
+	m := &Middleware{}
+	option := WithEncodings(EncodingDeflate)
+	option(m)
+
+	if len(m.Encodings) != 1 || m.Encodings[0] != EncodingDeflate {
+		t.Errorf("WithEncodings() failed: got %v, expected [deflate]", m.Encodings)
+	}
+}
+
+func Test_WithExcludedContentTypes(t *testing.T) {
+	m := &Middleware{}
+	option := WithExcludedContentTypes("text/csv")
+	option(m)
+
+	if len(m.ExcludedContentTypes) != 1 || m.ExcludedContentTypes[0] != "text/csv" {
+		t.Errorf("WithExcludedContentTypes() failed: got %v, expected [text/csv]", m.ExcludedContentTypes)
+	}
+}
@@ -0,0 +1,220 @@
+package compression
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net"
+	"net/http"
+)
+
+type compressWriter struct {
+	writer      http.ResponseWriter
+	middleware  *Middleware
+	encoding    string
+	encoder     io.WriteCloser
+	buffer      []byte
+	status      int
+	decided     bool
+	compress    bool
+	wroteHeader bool
+}
+
+type compressFlusher struct{ w *compressWriter }
+type compressHijacker struct{ w *compressWriter }
+type compressPusher struct{ w *compressWriter }
+
+func newCompressWriter(w http.ResponseWriter, m *Middleware, encoding string) (*compressWriter, http.ResponseWriter) {
+	cw := &compressWriter{
+		writer:     w,
+		middleware: m,
+		encoding:   encoding,
+	}
+	f := compressFlusher{cw}
+	h := compressHijacker{cw}
+	p := compressPusher{cw}
+
+	_, flusher := w.(http.Flusher)
+	_, hijacker := w.(http.Hijacker)
+	_, pusher := w.(http.Pusher)
+	switch {
+	case flusher && hijacker && pusher:
+		return cw, struct {
+			*compressWriter
+			compressFlusher
+			compressHijacker
+			compressPusher
+		}{cw, f, h, p}
+	case flusher && hijacker:
+		return cw, struct {
+			*compressWriter
+			compressFlusher
+			compressHijacker
+		}{cw, f, h}
+	case flusher && pusher:
+		return cw, struct {
+			*compressWriter
+			compressFlusher
+			compressPusher
+		}{cw, f, p}
+	case hijacker && pusher:
+		return cw, struct {
+			*compressWriter
+			compressHijacker
+			compressPusher
+		}{cw, h, p}
+	case flusher:
+		return cw, struct {
+			*compressWriter
+			compressFlusher
+		}{cw, f}
+	case hijacker:
+		return cw, struct {
+			*compressWriter
+			compressHijacker
+		}{cw, h}
+	case pusher:
+		return cw, struct {
+			*compressWriter
+			compressPusher
+		}{cw, p}
+	}
+	return cw, cw
+}
+
+func (w *compressWriter) Header() http.Header {
+	return w.writer.Header()
+}
+
+func (w *compressWriter) WriteHeader(statusCode int) {
+	if w.status != 0 || w.wroteHeader {
+		return
+	}
+	if statusCode >= 100 && statusCode < 200 && statusCode != http.StatusSwitchingProtocols {
+		w.writer.WriteHeader(statusCode)
+		return
+	}
+	w.status = statusCode
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.decided {
+		if w.compress {
+			return w.encoder.Write(b)
+		}
+		return w.writer.Write(b)
+	}
+
+	w.buffer = append(w.buffer, b...)
+	if len(w.buffer) >= w.middleware.MinLength {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		if w.status == 0 && len(w.buffer) == 0 {
+			return nil
+		}
+		if err := w.decide(len(w.buffer) >= w.middleware.MinLength); err != nil {
+			return err
+		}
+	}
+	if w.compress {
+		err := w.encoder.Close()
+		w.middleware.releaseEncoder(w.encoding, w.encoder)
+		w.encoder = nil
+		w.compress = false
+		return err
+	}
+	return nil
+}
+
+func (w *compressWriter) abort() {
+	w.buffer = nil
+	if w.compress {
+		w.middleware.releaseEncoder(w.encoding, w.encoder)
+		w.encoder = nil
+		w.compress = false
+	}
+	w.decided = true
+}
+
+func (w *compressWriter) decide(allowed bool) error {
+	w.decided = true
+	header := w.writer.Header()
+
+	if header.Get("Content-Type") == "" && len(w.buffer) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buffer))
+	}
+	w.compress = allowed && w.isCompressible(header)
+	if w.compress {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.encoding)
+		w.encoder = w.middleware.acquireEncoder(w.encoding, w.writer)
+	}
+
+	w.wroteHeader = true
+	w.writer.WriteHeader(w.status)
+
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) == 0 {
+		return nil
+	}
+	var err error
+	if w.compress {
+		_, err = w.encoder.Write(buffer)
+	} else {
+		_, err = w.writer.Write(buffer)
+	}
+	return err
+}
+
+func (w *compressWriter) isCompressible(header http.Header) bool {
+	if w.status < http.StatusOK || w.status == http.StatusNoContent || w.status == http.StatusNotModified || w.status == http.StatusPartialContent {
+		return false
+	}
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+	return !isExcludedContentType(header.Get("Content-Type"), w.middleware.ExcludedContentTypes)
+}
+
+func (f compressFlusher) Flush() {
+	if !f.w.decided {
+		if f.w.status == 0 {
+			f.w.status = http.StatusOK
+		}
+		f.w.decide(true)
+	}
+	if f.w.compress {
+		switch encoder := f.w.encoder.(type) {
+		case *gzip.Writer:
+			encoder.Flush()
+		case *zlib.Writer:
+			encoder.Flush()
+		}
+	}
+	f.w.writer.(http.Flusher).Flush()
+}
+
+func (h compressHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.w.decided = true
+	return h.w.writer.(http.Hijacker).Hijack()
+}
+
+func (p compressPusher) Push(target string, opts *http.PushOptions) error {
+	return p.w.writer.(http.Pusher).Push(target, opts)
+}
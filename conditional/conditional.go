@@ -0,0 +1,174 @@
+package conditional
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	metadataKey       router.MetadataKey = "router::conditional"
+	middlewareKey     router.MetadataKey = "router::conditional::middleware"
+	DefaultBufferSize int                = 1 << 20
+)
+
+var (
+	ErrValidatorUnavailable = errors.New("no validators available for the resource")
+)
+
+type Validators struct {
+	ETag         string
+	LastModified time.Time
+}
+
+type ValidatorFunc func(r *http.Request) (Validators, bool, error)
+
+type registration struct {
+	middleware *Middleware
+	index      int
+}
+
+func Disabled() router.RouteOption {
+	return func(r *router.Route) {
+		r.SetMetadata(metadataKey, false)
+	}
+}
+
+func IsRouteDisabled(route *router.Route) bool {
+	if route == nil {
+		return false
+	}
+	value, ok := route.GetMetadata(metadataKey)
+	if !ok {
+		return false
+	}
+	enabled, ok := value.(bool)
+	return ok && !enabled
+}
+
+func getReadHandler(route *router.Route, req *http.Request) http.Handler {
+	if route == nil || route.GetNode() == nil {
+		return nil
+	}
+	for _, candidate := range route.GetNode().Routes {
+		if candidate.HasHandler() && candidate.IsMethodAllowed(http.MethodGet) && candidate.IsMatch(req) {
+			return candidate.GetHandler()
+		}
+	}
+	return nil
+}
+
+func getInnerMiddlewares(route *router.Route, m *Middleware) []router.Middleware {
+	routers := make([]*router.Router, 0)
+	for node := route.GetNode(); node != nil; node = node.Parent {
+		if node.Router != nil {
+			routers = append(routers, node.Router)
+		}
+	}
+	slices.Reverse(routers)
+
+	var middlewares []router.Middleware
+	for _, r := range routers {
+		if middlewares != nil {
+			middlewares = append(middlewares, r.Middlewares()...)
+			continue
+		}
+		if value, ok := r.GetMetadata(middlewareKey); ok {
+			if reg, ok := value.(registration); ok && reg.middleware == m {
+				middlewares = append(make([]router.Middleware, 0), r.Middlewares()[reg.index+1:]...)
+			}
+		}
+	}
+	return middlewares
+}
+
+func ComputeETag(body []byte, weak bool) string {
+	sum := sha256.Sum256(body)
+	tag := `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+	if weak {
+		return "W/" + tag
+	}
+	return tag
+}
+
+func parseETags(value string) []string {
+	tags := make([]string, 0)
+	for value != "" {
+		value = strings.TrimLeft(value, " \t,")
+		if value == "" {
+			break
+		}
+		if value[0] == '*' {
+			tags = append(tags, "*")
+			value = value[1:]
+			continue
+		}
+		start := 0
+		if strings.HasPrefix(value, "W/") {
+			start = 2
+		}
+		if len(value) <= start || value[start] != '"' {
+			break
+		}
+		end := strings.IndexByte(value[start+1:], '"')
+		if end == -1 {
+			break
+		}
+		end += start + 2
+		tags = append(tags, value[:end])
+		value = value[end:]
+	}
+	return tags
+}
+
+func isWeak(tag string) bool {
+	return strings.HasPrefix(tag, "W/")
+}
+
+func opaqueTag(tag string) string {
+	return strings.TrimPrefix(tag, "W/")
+}
+
+func strongMatch(a string, b string) bool {
+	return !isWeak(a) && !isWeak(b) && a == b
+}
+
+func weakMatch(a string, b string) bool {
+	return opaqueTag(a) == opaqueTag(b)
+}
+
+func matchesAny(header string, etag string, exists bool, match func(a string, b string) bool) bool {
+	for _, tag := range parseETags(header) {
+		if tag == "*" {
+			if exists {
+				return true
+			}
+			continue
+		}
+		if etag != "" && match(tag, etag) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func isModifiedSince(lastModified time.Time, since time.Time) bool {
+	return lastModified.Truncate(time.Second).After(since)
+}
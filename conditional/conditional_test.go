@@ -0,0 +1,98 @@
+package conditional
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_ComputeETag(t *testing.T) {
+	strong := ComputeETag([]byte("hello"), false)
+	weak := ComputeETag([]byte("hello"), true)
+
+	if strong[0] != '"' || strong[len(strong)-1] != '"' {
+		t.Errorf("ComputeETag(strong) failed: got %v, expected quoted value", strong)
+	}
+	if weak != "W/"+strong {
+		t.Errorf("ComputeETag(weak) failed: got %v, expected W/%v", weak, strong)
+	}
+	if ComputeETag([]byte("other"), false) == strong {
+		t.Error("ComputeETag() failed: different bodies produce the same tag")
+	}
+}
+
+func Test_parseETags(t *testing.T) {
+	type testCase struct {
+		value    string
+		expected []string
+	}
+	tests := []testCase{
+		{"", []string{}},
+		{"*", []string{"*"}},
+		{`"a"`, []string{`"a"`}},
+		{`"a", W/"b" ,"c,d"`, []string{`"a"`, `W/"b"`, `"c,d"`}},
+		{`"a", invalid`, []string{`"a"`}},
+	}
+
+	for _, tc := range tests {
+		result := parseETags(tc.value)
+		if !slices.Equal(result, tc.expected) {
+			t.Errorf("parseETags(%s) failed: got %v, expected %v", tc.value, result, tc.expected)
+		}
+	}
+}
+
+func Test_matchesAny(t *testing.T) {
+	type testCase struct {
+		header   string
+		etag     string
+		exists   bool
+		strong   bool
+		expected bool
+	}
+	tests := []testCase{
+		{`"a"`, `"a"`, true, true, true},
+		{`W/"a"`, `"a"`, true, true, false},
+		{`W/"a"`, `"a"`, true, false, true},
+		{`"b", "a"`, `"a"`, true, true, true},
+		{`"b"`, `"a"`, true, false, false},
+		{"*", `"a"`, true, true, true},
+		{"*", "", false, true, false},
+	}
+
+	for _, tc := range tests {
+		match := weakMatch
+		if tc.strong {
+			match = strongMatch
+		}
+		result := matchesAny(tc.header, tc.etag, tc.exists, match)
+		if result != tc.expected {
+			t.Errorf("matchesAny(%s, %s, strong=%v) failed: got %v, expected %v", tc.header, tc.etag, tc.strong, result, tc.expected)
+		}
+	}
+}
+
+func Test_isModifiedSince(t *testing.T) {
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if isModifiedSince(since.Add(500*time.Millisecond), since) {
+		t.Error("isModifiedSince(sub-second) failed: got true, expected false")
+	}
+	if !isModifiedSince(since.Add(time.Second), since) {
+		t.Error("isModifiedSince(later) failed: got false, expected true")
+	}
+}
+
+func Test_Disabled(t *testing.T) {
+	route := &router.Route{}
+	if IsRouteDisabled(route) {
+		t.Error("IsRouteDisabled(default) failed: got true, expected false")
+	}
+
+	option := Disabled()
+	option(route)
+	if !IsRouteDisabled(route) {
+		t.Error("Disabled() failed: route not disabled")
+	}
+}
@@ -0,0 +1,179 @@
+package conditional
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	WeakETags     bool
+	BufferSize    int
+	ValidatorFunc ValidatorFunc
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.SetMetadata(middlewareKey, registration{middleware: m, index: len(router.Middlewares())})
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsRouteDisabled(router.CurrentRoute(r)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			m.serveRead(w, r, next)
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+			if m.checkPreconditions(w, r) {
+				next.ServeHTTP(w, r)
+			}
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.BufferSize <= 0 {
+		m.BufferSize = DefaultBufferSize
+	}
+	if m.ValidatorFunc == nil {
+		m.ValidatorFunc = m.RouteValidator
+	}
+}
+
+func (m *Middleware) RouteValidator(r *http.Request) (Validators, bool, error) {
+	req := r.Clone(r.Context())
+	req.Method = http.MethodGet
+	req.Body = http.NoBody
+	req.ContentLength = 0
+	for _, name := range []string{"If-Match", "If-None-Match", "If-Modified-Since", "If-Unmodified-Since", "Content-Type", "Content-Length"} {
+		req.Header.Del(name)
+	}
+
+	route := router.CurrentRoute(r)
+	handler := getReadHandler(route, req)
+	if handler == nil {
+		return Validators{}, false, ErrValidatorUnavailable
+	}
+
+	// Only the middleware registered after this one runs, the same chain the ETag of a GET response is computed from
+	middlewares := getInnerMiddlewares(route, m)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i].Middleware(handler)
+	}
+	rsp := newRecorder()
+	handler.ServeHTTP(rsp, req)
+	if rsp.status != 0 && rsp.status != http.StatusOK {
+		return Validators{}, false, nil
+	}
+	return m.validatorsOf(rsp.header, rsp.body.Bytes()), true, nil
+}
+
+func (m *Middleware) serveRead(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	bw, rw := newBufferWriter(w, m.BufferSize)
+	next.ServeHTTP(rw, r)
+	if bw.passthrough {
+		return
+	}
+	if bw.status == 0 {
+		bw.status = http.StatusOK
+	}
+
+	if bw.status == http.StatusOK {
+		validators := m.validatorsOf(bw.header, bw.buffer.Bytes())
+		if validators.ETag != "" {
+			bw.header.Set("ETag", validators.ETag)
+		}
+		if m.isNotModified(r, validators) {
+			bw.header.Del("Content-Type")
+			bw.header.Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.WriteHeader(bw.status)
+	w.Write(bw.buffer.Bytes())
+}
+
+func (m *Middleware) validatorsOf(header http.Header, body []byte) Validators {
+	validators := Validators{
+		ETag: header.Get("ETag"),
+	}
+	if validators.ETag == "" {
+		validators.ETag = ComputeETag(body, m.WeakETags)
+	}
+	if lastModified, ok := parseTime(header.Get("Last-Modified")); ok {
+		validators.LastModified = lastModified
+	}
+	return validators
+}
+
+func (m *Middleware) isNotModified(r *http.Request, validators Validators) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return matchesAny(ifNoneMatch, validators.ETag, true, weakMatch)
+	}
+	if since, ok := parseTime(r.Header.Get("If-Modified-Since")); ok && !validators.LastModified.IsZero() {
+		return !isModifiedSince(validators.LastModified, since)
+	}
+	return false
+}
+
+func (m *Middleware) checkPreconditions(w http.ResponseWriter, r *http.Request) bool {
+	ifMatch := r.Header.Get("If-Match")
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	ifUnmodifiedSince, hasUnmodifiedSince := parseTime(r.Header.Get("If-Unmodified-Since"))
+	if ifMatch == "" && ifNoneMatch == "" && !hasUnmodifiedSince {
+		return true
+	}
+	if m.ValidatorFunc == nil {
+		return true
+	}
+
+	validators, exists, err := m.ValidatorFunc(r)
+	if errors.Is(err, ErrValidatorUnavailable) {
+		return true
+	}
+	if err != nil {
+		router.Error(w, r, router.NewProblem(http.StatusInternalServerError, ""))
+		return false
+	}
+
+	// Generated weak ETags can never match strongly, so If-Match compares them weakly
+	match := strongMatch
+	if m.WeakETags {
+		match = weakMatch
+	}
+	failed := false
+	if ifMatch != "" {
+		failed = !matchesAny(ifMatch, validators.ETag, exists, match)
+	} else if hasUnmodifiedSince && exists && !validators.LastModified.IsZero() {
+		failed = isModifiedSince(validators.LastModified, ifUnmodifiedSince)
+	}
+	if !failed && ifNoneMatch != "" {
+		failed = matchesAny(ifNoneMatch, validators.ETag, exists, weakMatch)
+	}
+	if failed {
+		router.Error(w, r, router.NewProblem(http.StatusPreconditionFailed, ""))
+		return false
+	}
+	return true
+}
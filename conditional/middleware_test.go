@@ -0,0 +1,367 @@
+package conditional
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.BufferSize != DefaultBufferSize {
+			t.Errorf("NewMiddleware() failed: got buffer size %v, expected %v", m.BufferSize, DefaultBufferSize)
+		}
+		if m.ValidatorFunc == nil {
+			t.Error("NewMiddleware() failed: Default validator not set")
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+type resource struct {
+	body         string
+	lastModified time.Time
+	updates      int
+}
+
+func newTestRouter(res *resource) *router.Router {
+	r := router.NewRouter()
+	UseMiddleware(r)
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if res.body == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if !res.lastModified.IsZero() {
+			w.Header().Set("Last-Modified", res.lastModified.UTC().Format(http.TimeFormat))
+		}
+		w.Write([]byte(res.body))
+	}).AllowedMethod(http.MethodGet)
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		res.updates++
+		res.body = "updated"
+		w.WriteHeader(http.StatusNoContent)
+	}).AllowedMethods(http.MethodPut, http.MethodDelete)
+	return r
+}
+
+func Test_Middleware_ETag(t *testing.T) {
+	r := newTestRouter(&resource{body: "hello"})
+	etag := ComputeETag([]byte("hello"), false)
+
+	type testCase struct {
+		ifNoneMatch string
+		expected    int
+	}
+	tests := []testCase{
+		{"", http.StatusOK},
+		{etag, http.StatusNotModified},
+		{"W/" + etag, http.StatusNotModified},
+		{`"other", ` + etag, http.StatusNotModified},
+		{`"other"`, http.StatusOK},
+		{"*", http.StatusNotModified},
+	}
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		if tc.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+		}
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if rsp.Code != tc.expected {
+			t.Errorf("Middleware(If-None-Match: %s) failed: got status %v, expected %v", tc.ifNoneMatch, rsp.Code, tc.expected)
+		}
+		if rsp.Header().Get("ETag") != etag {
+			t.Errorf("Middleware(If-None-Match: %s) failed: got ETag %v, expected %v", tc.ifNoneMatch, rsp.Header().Get("ETag"), etag)
+		}
+		if tc.expected == http.StatusNotModified && rsp.Body.Len() != 0 {
+			t.Errorf("Middleware(If-None-Match: %s) failed: body sent with 304", tc.ifNoneMatch)
+		}
+		if tc.expected == http.StatusOK && rsp.Body.String() != "hello" {
+			t.Errorf("Middleware(If-None-Match: %s) failed: got body %v, expected hello", tc.ifNoneMatch, rsp.Body.String())
+		}
+	}
+}
+
+func Test_Middleware_LastModified(t *testing.T) {
+	lastModified := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := newTestRouter(&resource{body: "hello", lastModified: lastModified})
+
+	type testCase struct {
+		ifModifiedSince time.Time
+		expected        int
+	}
+	tests := []testCase{
+		{lastModified, http.StatusNotModified},
+		{lastModified.Add(time.Hour), http.StatusNotModified},
+		{lastModified.Add(-time.Hour), http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Header.Set("If-Modified-Since", tc.ifModifiedSince.Format(http.TimeFormat))
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if rsp.Code != tc.expected {
+			t.Errorf("Middleware(If-Modified-Since: %v) failed: got status %v, expected %v", tc.ifModifiedSince, rsp.Code, tc.expected)
+		}
+	}
+}
+
+func Test_Middleware_IfMatch(t *testing.T) {
+	etag := ComputeETag([]byte("hello"), false)
+
+	type testCase struct {
+		body     string
+		method   string
+		ifMatch  string
+		expected int
+	}
+	tests := []testCase{
+		{"hello", http.MethodPut, etag, http.StatusNoContent},
+		{"hello", http.MethodPut, `"stale"`, http.StatusPreconditionFailed},
+		{"hello", http.MethodPut, "W/" + etag, http.StatusPreconditionFailed},
+		{"hello", http.MethodDelete, "*", http.StatusNoContent},
+		{"", http.MethodDelete, "*", http.StatusPreconditionFailed},
+		{"hello", http.MethodPut, "", http.StatusNoContent},
+	}
+
+	for _, tc := range tests {
+		res := &resource{body: tc.body}
+		r := newTestRouter(res)
+
+		req := httptest.NewRequest(tc.method, "/items/1", strings.NewReader("payload"))
+		if tc.ifMatch != "" {
+			req.Header.Set("If-Match", tc.ifMatch)
+		}
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if rsp.Code != tc.expected {
+			t.Errorf("Middleware(%s If-Match: %s) failed: got status %v, expected %v", tc.method, tc.ifMatch, rsp.Code, tc.expected)
+		}
+		expectedUpdates := 0
+		if tc.expected == http.StatusNoContent {
+			expectedUpdates = 1
+		}
+		if res.updates != expectedUpdates {
+			t.Errorf("Middleware(%s If-Match: %s) failed: handler called %v times, expected %v", tc.method, tc.ifMatch, res.updates, expectedUpdates)
+		}
+	}
+}
+
+func Test_Middleware_IfNoneMatchCreate(t *testing.T) {
+	type testCase struct {
+		body     string
+		expected int
+	}
+	tests := []testCase{
+		{"", http.StatusNoContent},
+		{"hello", http.StatusPreconditionFailed},
+	}
+
+	for _, tc := range tests {
+		r := newTestRouter(&resource{body: tc.body})
+		req := httptest.NewRequest(http.MethodPut, "/items/1", strings.NewReader("payload"))
+		req.Header.Set("If-None-Match", "*")
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if rsp.Code != tc.expected {
+			t.Errorf("Middleware(PUT If-None-Match: * exists=%v) failed: got status %v, expected %v", tc.body != "", rsp.Code, tc.expected)
+		}
+	}
+}
+
+func Test_Middleware_RouteValidator_InnerMiddleware(t *testing.T) {
+	outerCalls := 0
+	r := router.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			outerCalls++
+			next.ServeHTTP(w, r)
+		})
+	})
+	UseMiddleware(r)
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				w.Write([]byte("prefix:"))
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}).AllowedMethod(http.MethodGet)
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).AllowedMethod(http.MethodPut)
+
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	etag := rsp.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodPut, "/items/1", strings.NewReader("payload"))
+	req.Header.Set("If-Match", etag)
+	rsp = httptest.NewRecorder()
+	r.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusNoContent {
+		t.Errorf("Middleware(PUT If-Match) failed: got status %v, expected %v", rsp.Code, http.StatusNoContent)
+	}
+	if outerCalls != 2 {
+		t.Errorf("Middleware(PUT If-Match) failed: outer middleware called %v times, expected 2", outerCalls)
+	}
+}
+
+func Test_Middleware_IfMatch_WeakETags(t *testing.T) {
+	r := router.NewRouter()
+	UseMiddleware(r, WithWeakETags(true))
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}).AllowedMethod(http.MethodGet)
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).AllowedMethod(http.MethodPut)
+
+	req := httptest.NewRequest(http.MethodPut, "/items/1", strings.NewReader("payload"))
+	req.Header.Set("If-Match", ComputeETag([]byte("hello"), true))
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusNoContent {
+		t.Errorf("Middleware(PUT If-Match weak) failed: got status %v, expected %v", rsp.Code, http.StatusNoContent)
+	}
+}
+
+func Test_Middleware_RouteValidator_NoReadHandler(t *testing.T) {
+	res := &resource{}
+	r := router.NewRouter()
+	UseMiddleware(r)
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		res.updates++
+		w.WriteHeader(http.StatusNoContent)
+	}).AllowedMethod(http.MethodPut)
+
+	req := httptest.NewRequest(http.MethodPut, "/items/1", strings.NewReader("payload"))
+	req.Header.Set("If-Match", `"any"`)
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusNoContent || res.updates != 1 {
+		t.Errorf("Middleware(PUT without GET) failed: got status %v, expected %v", rsp.Code, http.StatusNoContent)
+	}
+}
+
+func Test_Middleware_BufferLimit(t *testing.T) {
+	m := NewMiddleware(WithBufferSize(4))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ab"))
+		w.Write([]byte("cdef"))
+	}))
+
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rsp.Body.String() != "abcdef" {
+		t.Errorf("Middleware(bufferLimit) failed: got body %v, expected abcdef", rsp.Body.String())
+	}
+	if rsp.Header().Get("ETag") != "" {
+		t.Error("Middleware(bufferLimit) failed: ETag set on streamed response")
+	}
+}
+
+func Test_Middleware_HandlerETag(t *testing.T) {
+	m := NewMiddleware()
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusNotModified {
+		t.Errorf("Middleware(handlerETag) failed: got status %v, expected %v", rsp.Code, http.StatusNotModified)
+	}
+}
+
+func Test_Middleware_Interfaces(t *testing.T) {
+	m := NewMiddleware()
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("Middleware(interfaces) failed: flusher not exposed")
+		}
+		if _, ok := w.(http.Hijacker); ok {
+			t.Error("Middleware(interfaces) failed: hijacker exposed for a writer without it")
+		}
+	}))
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func Test_Middleware_Upgrade(t *testing.T) {
+	r := router.NewRouter()
+	UseMiddleware(r)
+	r.HandleFunc("/socket", func(w http.ResponseWriter, r *http.Request) {
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			http.Error(w, "no hijacker", http.StatusInternalServerError)
+			return
+		}
+		conn, rw, err := hijacker.Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n")
+		rw.Flush()
+	}).AllowedMethod(http.MethodGet)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/socket", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "test")
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Middleware(upgrade) failed: %v", err)
+	}
+	rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusSwitchingProtocols {
+		t.Errorf("Middleware(upgrade) failed: got status %v, expected %v", rsp.StatusCode, http.StatusSwitchingProtocols)
+	}
+}
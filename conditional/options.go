@@ -0,0 +1,19 @@
+package conditional
+
+func WithWeakETags(weak bool) MiddlewareOption {
+	return func(m *Middleware) {
+		m.WeakETags = weak
+	}
+}
+
+func WithBufferSize(size int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.BufferSize = size
+	}
+}
+
+func WithValidatorFunc(validator ValidatorFunc) MiddlewareOption {
+	return func(m *Middleware) {
+		m.ValidatorFunc = validator
+	}
+}
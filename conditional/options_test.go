@@ -0,0 +1,38 @@
+package conditional
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_WithWeakETags(t *testing.T) {
+	m := &Middleware{}
+	option := WithWeakETags(true)
+	option(m)
+
+	if !m.WeakETags {
+		t.Error("WithWeakETags() failed: got false, expected true")
+	}
+}
+
+func Test_WithBufferSize(t *testing.T) {
+	m := &Middleware{}
+	option := WithBufferSize(10)
+	option(m)
+
+	if m.BufferSize != 10 {
+		t.Errorf("WithBufferSize() failed: got %v, expected 10", m.BufferSize)
+	}
+}
+
+func Test_WithValidatorFunc(t *testing.T) {
+	m := &Middleware{}
+	option := WithValidatorFunc(func(r *http.Request) (Validators, bool, error) {
+		return Validators{}, false, nil
+	})
+	option(m)
+
+	if m.ValidatorFunc == nil {
+		t.Error("WithValidatorFunc() failed: validator not set")
+	}
+}
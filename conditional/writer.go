@@ -0,0 +1,194 @@
+package conditional
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+)
+
+type bufferWriter struct {
+	writer      http.ResponseWriter
+	header      http.Header
+	buffer      bytes.Buffer
+	status      int
+	limit       int
+	passthrough bool
+}
+
+type bufferFlusher struct{ w *bufferWriter }
+type bufferHijacker struct{ w *bufferWriter }
+type bufferPusher struct{ w *bufferWriter }
+
+const (
+	capabilityFlusher = 1 << iota
+	capabilityHijacker
+	capabilityPusher
+)
+
+func newBufferWriter(w http.ResponseWriter, limit int) (*bufferWriter, http.ResponseWriter) {
+	bw := &bufferWriter{
+		writer: w,
+		header: w.Header(),
+		limit:  limit,
+	}
+	f := bufferFlusher{bw}
+	h := bufferHijacker{bw}
+	p := bufferPusher{bw}
+
+	capabilities := 0
+	if _, ok := w.(http.Flusher); ok {
+		capabilities |= capabilityFlusher
+	}
+	if _, ok := w.(http.Hijacker); ok {
+		capabilities |= capabilityHijacker
+	}
+	if _, ok := w.(http.Pusher); ok {
+		capabilities |= capabilityPusher
+	}
+
+	switch capabilities {
+	case capabilityFlusher:
+		return bw, struct {
+			*bufferWriter
+			bufferFlusher
+		}{bw, f}
+	case capabilityHijacker:
+		return bw, struct {
+			*bufferWriter
+			bufferHijacker
+		}{bw, h}
+	case capabilityFlusher | capabilityHijacker:
+		return bw, struct {
+			*bufferWriter
+			bufferFlusher
+			bufferHijacker
+		}{bw, f, h}
+	case capabilityPusher:
+		return bw, struct {
+			*bufferWriter
+			bufferPusher
+		}{bw, p}
+	case capabilityFlusher | capabilityPusher:
+		return bw, struct {
+			*bufferWriter
+			bufferFlusher
+			bufferPusher
+		}{bw, f, p}
+	case capabilityHijacker | capabilityPusher:
+		return bw, struct {
+			*bufferWriter
+			bufferHijacker
+			bufferPusher
+		}{bw, h, p}
+	case capabilityFlusher | capabilityHijacker | capabilityPusher:
+		return bw, struct {
+			*bufferWriter
+			bufferFlusher
+			bufferHijacker
+			bufferPusher
+		}{bw, f, h, p}
+	}
+	return bw, bw
+}
+
+func (w *bufferWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferWriter) WriteHeader(statusCode int) {
+	if w.passthrough {
+		w.writer.WriteHeader(statusCode)
+		return
+	}
+	if w.status != 0 {
+		return
+	}
+	if statusCode >= 100 && statusCode < 200 {
+		if statusCode == http.StatusSwitchingProtocols {
+			w.passthrough = true
+		}
+		w.writer.WriteHeader(statusCode)
+		return
+	}
+	w.status = statusCode
+}
+
+func (w *bufferWriter) Write(b []byte) (int, error) {
+	if w.passthrough {
+		return w.writer.Write(b)
+	}
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.buffer.Len()+len(b) > w.limit {
+		if err := w.startPassthrough(); err != nil {
+			return 0, err
+		}
+		return w.writer.Write(b)
+	}
+	return w.buffer.Write(b)
+}
+
+func (w *bufferWriter) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
+func (w *bufferWriter) startPassthrough() error {
+	w.passthrough = true
+	w.writer.WriteHeader(w.status)
+	_, err := w.writer.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+func (f bufferFlusher) Flush() {
+	if !f.w.passthrough {
+		if f.w.status == 0 {
+			f.w.status = http.StatusOK
+		}
+		f.w.startPassthrough()
+	}
+	f.w.writer.(http.Flusher).Flush()
+}
+
+func (h bufferHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := h.w.writer.(http.Hijacker).Hijack()
+	if err == nil {
+		h.w.passthrough = true
+	}
+	return conn, rw, err
+}
+
+func (p bufferPusher) Push(target string, opts *http.PushOptions) error {
+	return p.w.writer.(http.Pusher).Push(target, opts)
+}
+
+type recorder struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		header: make(http.Header),
+	}
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) WriteHeader(statusCode int) {
+	if r.status == 0 {
+		r.status = statusCode
+	}
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
@@ -0,0 +1,78 @@
+package cors
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::cors"
+
+	HeaderOrigin                        string = "Origin"
+	HeaderAccessControlRequestMethod    string = "Access-Control-Request-Method"
+	HeaderAccessControlRequestHeaders   string = "Access-Control-Request-Headers"
+	HeaderAccessControlAllowOrigin      string = "Access-Control-Allow-Origin"
+	HeaderAccessControlAllowCredentials string = "Access-Control-Allow-Credentials"
+	HeaderAccessControlAllowMethods     string = "Access-Control-Allow-Methods"
+	HeaderAccessControlAllowHeaders     string = "Access-Control-Allow-Headers"
+	HeaderAccessControlExposeHeaders    string = "Access-Control-Expose-Headers"
+	HeaderAccessControlMaxAge           string = "Access-Control-Max-Age"
+)
+
+var DefaultAllowedHeaders = []string{
+	"Accept",
+	"Accept-Language",
+	"Authorization",
+	"Content-Language",
+	"Content-Type",
+	"X-Request-ID",
+}
+
+func getRouterMiddleware(route *router.Route) *Middleware {
+	if route == nil {
+		return nil
+	}
+	for node := route.GetNode(); node != nil; node = node.Parent {
+		if node.Router == nil {
+			continue
+		}
+		if value, ok := node.Router.GetMetadata(metadataKey); ok {
+			return value.(*Middleware)
+		}
+	}
+	return nil
+}
+
+func getAllowedMethods(route *router.Route) []string {
+	if route == nil || route.GetNode() == nil {
+		return nil
+	}
+	return route.GetNode().GetAllowedMethods()
+}
+
+func matchOrigin(pattern string, origin string) bool {
+	if pattern == "*" {
+		return true
+	}
+	prefix, suffix, wildcard := strings.Cut(pattern, "*")
+	if !wildcard {
+		return strings.EqualFold(pattern, origin)
+	}
+	origin = strings.ToLower(origin)
+	prefix = strings.ToLower(prefix)
+	suffix = strings.ToLower(suffix)
+	return len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+}
+
+func parseHeaderList(value string) []string {
+	headers := make([]string, 0)
+	for _, header := range strings.Split(value, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" {
+			headers = append(headers, http.CanonicalHeaderKey(header))
+		}
+	}
+	return headers
+}
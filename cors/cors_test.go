@@ -0,0 +1,39 @@
+package cors
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_matchOrigin(t *testing.T) {
+	type testCase struct {
+		pattern  string
+		origin   string
+		expected bool
+	}
+	tests := []testCase{
+		{"*", "https://example.com", true},
+		{"https://example.com", "https://example.com", true},
+		{"https://example.com", "https://EXAMPLE.com", true},
+		{"https://example.com", "https://example.org", false},
+		{"https://*.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://app.example.com.evil.org", false},
+		{"http://localhost:*", "http://localhost:3000", true},
+	}
+
+	for _, tc := range tests {
+		result := matchOrigin(tc.pattern, tc.origin)
+		if result != tc.expected {
+			t.Errorf("matchOrigin(%s, %s) failed: got %v, expected %v", tc.pattern, tc.origin, result, tc.expected)
+		}
+	}
+}
+
+func Test_parseHeaderList(t *testing.T) {
+	result := parseHeaderList("content-type, x-custom ,,authorization")
+	expected := []string{"Content-Type", "X-Custom", "Authorization"}
+	if !slices.Equal(result, expected) {
+		t.Errorf("parseHeaderList() failed: got %v, expected %v", result, expected)
+	}
+}
@@ -0,0 +1,150 @@
+package cors
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	AllowedOrigins   []string
+	AllowOriginFunc  func(origin string) bool
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.SetMetadata(metadataKey, m)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := router.CurrentRoute(r)
+		if nearest := getRouterMiddleware(route); nearest != nil && nearest != m {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", HeaderOrigin)
+		origin := r.Header.Get(HeaderOrigin)
+		preflight := r.Method == http.MethodOptions && r.Header.Get(HeaderAccessControlRequestMethod) != ""
+		if preflight {
+			m.handlePreflight(w, r, route, origin)
+			return
+		}
+
+		if origin != "" && m.IsOriginAllowed(origin) {
+			m.setOriginHeaders(w, origin)
+			if len(m.ExposedHeaders) > 0 {
+				w.Header().Set(HeaderAccessControlExposeHeaders, strings.Join(m.ExposedHeaders, ", "))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.AllowedOrigins == nil {
+		m.AllowedOrigins = make([]string, 0)
+	}
+	if m.AllowedHeaders == nil {
+		m.AllowedHeaders = DefaultAllowedHeaders
+	}
+	if m.ExposedHeaders == nil {
+		m.ExposedHeaders = make([]string, 0)
+	}
+}
+
+func (m *Middleware) IsOriginAllowed(origin string) bool {
+	return m.isOriginListed(origin) || slices.Contains(m.AllowedOrigins, "*")
+}
+
+func (m *Middleware) IsHeaderAllowed(header string) bool {
+	for _, allowed := range m.AllowedHeaders {
+		if allowed == "*" || strings.EqualFold(allowed, header) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Middleware) handlePreflight(w http.ResponseWriter, r *http.Request, route *router.Route, origin string) {
+	header := w.Header()
+	header.Add("Vary", HeaderAccessControlRequestMethod)
+	header.Add("Vary", HeaderAccessControlRequestHeaders)
+
+	if origin == "" || !m.IsOriginAllowed(origin) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	methods := getAllowedMethods(route)
+	if !slices.Contains(methods, r.Header.Get(HeaderAccessControlRequestMethod)) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	requestedHeaders := parseHeaderList(r.Header.Get(HeaderAccessControlRequestHeaders))
+	for _, requested := range requestedHeaders {
+		if !m.IsHeaderAllowed(requested) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	m.setOriginHeaders(w, origin)
+	header.Set(HeaderAccessControlAllowMethods, strings.Join(methods, ", "))
+	if len(requestedHeaders) > 0 {
+		header.Set(HeaderAccessControlAllowHeaders, strings.Join(requestedHeaders, ", "))
+	}
+	if m.MaxAge > 0 {
+		header.Set(HeaderAccessControlMaxAge, strconv.Itoa(int(m.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *Middleware) setOriginHeaders(w http.ResponseWriter, origin string) {
+	header := w.Header()
+	// Credentials are only shared with origins that are explicitly allowed, never through the "*" wildcard
+	credentials := m.AllowCredentials && m.isOriginListed(origin)
+	if !credentials && slices.Contains(m.AllowedOrigins, "*") {
+		header.Set(HeaderAccessControlAllowOrigin, "*")
+	} else {
+		header.Set(HeaderAccessControlAllowOrigin, origin)
+	}
+	if credentials {
+		header.Set(HeaderAccessControlAllowCredentials, "true")
+	}
+}
+
+func (m *Middleware) isOriginListed(origin string) bool {
+	if m.AllowOriginFunc != nil && m.AllowOriginFunc(origin) {
+		return true
+	}
+	for _, pattern := range m.AllowedOrigins {
+		if pattern != "*" && matchOrigin(pattern, origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.AllowedHeaders == nil {
+			t.Error("NewMiddleware() failed: Default allowed headers not set")
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+	if _, ok := router.GetMetadata(metadataKey); !ok {
+		t.Error("UseMiddleware() failed: Middleware not registered on router")
+	}
+}
+
+func Test_Middleware_Preflight(t *testing.T) {
+	handlerCalled := 0
+	handle := func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled++
+	}
+
+	r := router.NewRouter()
+	UseMiddleware(r,
+		WithAllowedOrigins("https://app.example.com"),
+		WithAllowCredentials(true),
+		WithMaxAge(10*time.Minute),
+	)
+	r.HandleFunc("/items/{id}", handle).AllowedMethod(http.MethodGet)
+	r.HandleFunc("/items/{id}", handle).AllowedMethod(http.MethodDelete)
+
+	type testCase struct {
+		origin        string
+		method        string
+		headers       string
+		expectedAllow bool
+	}
+	tests := []testCase{
+		{"https://app.example.com", http.MethodDelete, "content-type", true},
+		{"https://app.example.com", http.MethodPut, "", false},
+		{"https://app.example.com", http.MethodGet, "x-not-allowed", false},
+		{"https://evil.example.com", http.MethodGet, "", false},
+	}
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodOptions, "/items/1", nil)
+		req.Header.Set(HeaderOrigin, tc.origin)
+		req.Header.Set(HeaderAccessControlRequestMethod, tc.method)
+		if tc.headers != "" {
+			req.Header.Set(HeaderAccessControlRequestHeaders, tc.headers)
+		}
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if rsp.Code != http.StatusNoContent {
+			t.Errorf("Middleware(preflight %s %s) failed: got status %v, expected %v", tc.origin, tc.method, rsp.Code, http.StatusNoContent)
+		}
+		allowOrigin := rsp.Header().Get(HeaderAccessControlAllowOrigin)
+		if tc.expectedAllow != (allowOrigin == tc.origin) {
+			t.Errorf("Middleware(preflight %s %s) failed: got allow origin %v", tc.origin, tc.method, allowOrigin)
+		}
+		if !tc.expectedAllow {
+			continue
+		}
+		if methods := rsp.Header().Get(HeaderAccessControlAllowMethods); methods != "GET, DELETE, OPTIONS" {
+			t.Errorf("Middleware(preflight) failed: got allow methods %v, expected GET, DELETE, OPTIONS", methods)
+		}
+		if headers := rsp.Header().Get(HeaderAccessControlAllowHeaders); headers != "Content-Type" {
+			t.Errorf("Middleware(preflight) failed: got allow headers %v, expected Content-Type", headers)
+		}
+		if credentials := rsp.Header().Get(HeaderAccessControlAllowCredentials); credentials != "true" {
+			t.Errorf("Middleware(preflight) failed: got allow credentials %v, expected true", credentials)
+		}
+		if maxAge := rsp.Header().Get(HeaderAccessControlMaxAge); maxAge != "600" {
+			t.Errorf("Middleware(preflight) failed: got max age %v, expected 600", maxAge)
+		}
+	}
+	if handlerCalled != 0 {
+		t.Errorf("Middleware(preflight) failed: handler called %v times, expected 0", handlerCalled)
+	}
+}
+
+func Test_Middleware_Simple(t *testing.T) {
+	r := router.NewRouter()
+	UseMiddleware(r,
+		WithAllowedOrigins("*"),
+		WithExposedHeaders("X-Total-Count"),
+	)
+	r.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set(HeaderOrigin, "https://any.example.com")
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusOK {
+		t.Errorf("Middleware(simple) failed: got status %v, expected %v", rsp.Code, http.StatusOK)
+	}
+	if origin := rsp.Header().Get(HeaderAccessControlAllowOrigin); origin != "*" {
+		t.Errorf("Middleware(simple) failed: got allow origin %v, expected *", origin)
+	}
+	if exposed := rsp.Header().Get(HeaderAccessControlExposeHeaders); exposed != "X-Total-Count" {
+		t.Errorf("Middleware(simple) failed: got exposed headers %v, expected X-Total-Count", exposed)
+	}
+	if vary := rsp.Header().Get("Vary"); vary != HeaderOrigin {
+		t.Errorf("Middleware(simple) failed: got vary %v, expected Origin", vary)
+	}
+}
+
+func Test_Middleware_WildcardCredentials(t *testing.T) {
+	r := router.NewRouter()
+	UseMiddleware(r,
+		WithAllowedOrigins("*", "https://app.example.com"),
+		WithAllowCredentials(true),
+	)
+	r.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	type testCase struct {
+		origin              string
+		expectedOrigin      string
+		expectedCredentials string
+	}
+	tests := []testCase{
+		{"https://app.example.com", "https://app.example.com", "true"},
+		{"https://evil.example.com", "*", ""},
+	}
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.Header.Set(HeaderOrigin, tc.origin)
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if origin := rsp.Header().Get(HeaderAccessControlAllowOrigin); origin != tc.expectedOrigin {
+			t.Errorf("Middleware(%s) failed: got allow origin %v, expected %v", tc.origin, origin, tc.expectedOrigin)
+		}
+		if credentials := rsp.Header().Get(HeaderAccessControlAllowCredentials); credentials != tc.expectedCredentials {
+			t.Errorf("Middleware(%s) failed: got allow credentials %v, expected %v", tc.origin, credentials, tc.expectedCredentials)
+		}
+	}
+}
+
+func Test_Middleware_SubRouterOverride(t *testing.T) {
+	handle := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := router.NewRouter()
+	UseMiddleware(r, WithAllowedOrigins("https://public.example.com"))
+	r.HandleFunc("/public", handle)
+
+	sub := r.PathPrefix("/admin").SubRouter()
+	UseMiddleware(sub, WithAllowedOrigins("https://admin.example.com"))
+	sub.HandleFunc("/users", handle)
+
+	type testCase struct {
+		path     string
+		origin   string
+		expected string
+	}
+	tests := []testCase{
+		{"/public", "https://public.example.com", "https://public.example.com"},
+		{"/public", "https://admin.example.com", ""},
+		{"/admin/users", "https://admin.example.com", "https://admin.example.com"},
+		{"/admin/users", "https://public.example.com", ""},
+	}
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.Header.Set(HeaderOrigin, tc.origin)
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if origin := rsp.Header().Get(HeaderAccessControlAllowOrigin); origin != tc.expected {
+			t.Errorf("Middleware(%s, %s) failed: got allow origin %v, expected %v", tc.path, tc.origin, origin, tc.expected)
+		}
+	}
+}
+
+func Test_Middleware_AllowOriginFunc(t *testing.T) {
+	m := NewMiddleware(WithAllowOriginFunc(func(origin string) bool {
+		return origin == "https://dynamic.example.com"
+	}))
+
+	if !m.IsOriginAllowed("https://dynamic.example.com") {
+		t.Error("Middleware.IsOriginAllowed(dynamic) failed: got false, expected true")
+	}
+	if m.IsOriginAllowed("https://other.example.com") {
+		t.Error("Middleware.IsOriginAllowed(other) failed: got true, expected false")
+	}
+}
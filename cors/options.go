@@ -0,0 +1,41 @@
+package cors
+
+import (
+	"time"
+)
+
+func WithAllowedOrigins(origins ...string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.AllowedOrigins = append(m.AllowedOrigins, origins...)
+	}
+}
+
+func WithAllowOriginFunc(allow func(origin string) bool) MiddlewareOption {
+	return func(m *Middleware) {
+		m.AllowOriginFunc = allow
+	}
+}
+
+func WithAllowedHeaders(headers ...string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.AllowedHeaders = append(m.AllowedHeaders, headers...)
+	}
+}
+
+func WithExposedHeaders(headers ...string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.ExposedHeaders = append(m.ExposedHeaders, headers...)
+	}
+}
+
+func WithAllowCredentials(allow bool) MiddlewareOption {
+	return func(m *Middleware) {
+		m.AllowCredentials = allow
+	}
+}
+
+func WithMaxAge(maxAge time.Duration) MiddlewareOption {
+	return func(m *Middleware) {
+		m.MaxAge = maxAge
+	}
+}
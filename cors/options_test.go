@@ -0,0 +1,66 @@
+package cors
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_WithAllowedOrigins(t *testing.T) {
+	m := &Middleware{}
+	option := WithAllowedOrigins("https://example.com")
+	option(m)
+
+	if len(m.AllowedOrigins) != 1 || m.AllowedOrigins[0] != "https://example.com" {
+		t.Errorf("WithAllowedOrigins() failed: got %v, expected [https://example.com]", m.AllowedOrigins)
+	}
+}
+
+func Test_WithAllowOriginFunc(t *testing.T) {
+	m := &Middleware{}
+	option := WithAllowOriginFunc(func(origin string) bool { return true })
+	option(m)
+
+	if m.AllowOriginFunc == nil {
+		t.Error("WithAllowOriginFunc() failed: function not set")
+	}
+}
+
+func Test_WithAllowedHeaders(t *testing.T) {
+	m := &Middleware{}
+	option := WithAllowedHeaders("X-Custom")
+	option(m)
+
+	if len(m.AllowedHeaders) != 1 || m.AllowedHeaders[0] != "X-Custom" {
+		t.Errorf("WithAllowedHeaders() failed: got %v, expected [X-Custom]", m.AllowedHeaders)
+	}
+}
+
+func Test_WithExposedHeaders(t *testing.T) {
+	m := &Middleware{}
+	option := WithExposedHeaders("X-Total-Count")
+	option(m)
+
+	if len(m.ExposedHeaders) != 1 || m.ExposedHeaders[0] != "X-Total-Count" {
+		t.Errorf("WithExposedHeaders() failed: got %v, expected [X-Total-Count]", m.ExposedHeaders)
+	}
+}
+
+func Test_WithAllowCredentials(t *testing.T) {
+	m := &Middleware{}
+	option := WithAllowCredentials(true)
+	option(m)
+
+	if !m.AllowCredentials {
+		t.Error("WithAllowCredentials() failed: got false, expected true")
+	}
+}
+
+func Test_WithMaxAge(t *testing.T) {
+	m := &Middleware{}
+	option := WithMaxAge(time.Hour)
+	option(m)
+
+	if m.MaxAge != time.Hour {
+		t.Errorf("WithMaxAge() failed: got %v, expected %v", m.MaxAge, time.Hour)
+	}
+}
@@ -0,0 +1,192 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/binding"
+	"github.com/deb-ict/go-router/openapi"
+	"github.com/deb-ict/go-router/render"
+	"github.com/deb-ict/go-router/validation"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::endpoint::status"
+)
+
+type Func[Req any, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+func Status(status int) router.RouteOption {
+	return func(r *router.Route) {
+		r.SetMetadata(metadataKey, status)
+	}
+}
+
+func GetRouteStatus(route *router.Route) int {
+	if route != nil {
+		if value, ok := route.GetMetadata(metadataKey); ok {
+			if status, ok := value.(int); ok {
+				return status
+			}
+		}
+	}
+	return http.StatusOK
+}
+
+func Handle[Req any, Resp any](r *router.Router, pattern string, fn Func[Req, Resp], opts ...router.RouteOption) *router.Route {
+	route := r.Handle(pattern, Handler(fn), opts...)
+	if route == nil {
+		return nil
+	}
+	method := ""
+	if methods := route.GetMethods(); len(methods) > 0 {
+		method = methods[0]
+	}
+	describe[Req, Resp](route, method)
+	return route
+}
+
+func Handler[Req any, Resp any](fn Func[Req, Resp]) router.HandlerErrorFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		req, err := newRequest[Req]()
+		if err != nil {
+			return err
+		}
+		target := any(&req)
+		if reflect.TypeFor[Req]().Kind() == reflect.Pointer {
+			target = req
+		}
+
+		if err := binding.Bind(r, target); err != nil {
+			return err
+		}
+		ctx := validation.NewContext()
+		if !validation.Validate(target, ctx) {
+			return validation.NewError(ctx)
+		}
+
+		resp, err := fn(r.Context(), req)
+		if err != nil {
+			return err
+		}
+		return render.Render(w, r, GetRouteStatus(router.CurrentRoute(r)), resp)
+	}
+}
+
+func newRequest[Req any]() (Req, error) {
+	var req Req
+	t := reflect.TypeFor[Req]()
+	if t.Kind() == reflect.Pointer {
+		if t.Elem().Kind() != reflect.Struct {
+			return req, binding.ErrInvalidTarget
+		}
+		req = reflect.New(t.Elem()).Interface().(Req)
+	} else if t.Kind() != reflect.Struct {
+		return req, binding.ErrInvalidTarget
+	}
+	return req, nil
+}
+
+func describe[Req any, Resp any](route *router.Route, method string) {
+	info, _ := openapi.GetOperationInfo(route)
+	requestType := reflect.TypeFor[Req]()
+	if requestType.Kind() == reflect.Pointer {
+		requestType = requestType.Elem()
+	}
+	if requestType.Kind() == reflect.Struct {
+		describeParams(route, requestType)
+		if (info == nil || info.RequestBody == nil) && acceptsBody(method) && hasBody(requestType) {
+			openapi.Accepts(requestType)(route)
+		}
+	}
+
+	status := GetRouteStatus(route)
+	if info != nil {
+		for _, response := range info.Responses {
+			if response.Status == status {
+				return
+			}
+		}
+	}
+	var response any
+	if status != http.StatusNoContent {
+		response = reflect.TypeFor[Resp]()
+	}
+	openapi.Returns(status, response)(route)
+}
+
+func describeParams(route *router.Route, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			describeParams(route, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if name, ok := tagName(field, binding.TagPath); ok {
+			openapi.PathParam(name, fieldType)(route)
+		}
+		if name, ok := tagName(field, binding.TagQuery); ok {
+			openapi.QueryParam(name, fieldType, false)(route)
+		}
+		if name, ok := tagName(field, binding.TagHeader); ok {
+			openapi.HeaderParam(name, fieldType, false)(route)
+		}
+	}
+}
+
+func acceptsBody(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+func hasBody(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if hasBody(field.Type) {
+				return true
+			}
+			continue
+		}
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		if _, ok := tagName(field, binding.TagPath); ok {
+			continue
+		}
+		if _, ok := tagName(field, binding.TagQuery); ok {
+			continue
+		}
+		if _, ok := tagName(field, binding.TagHeader); ok {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func tagName(field reflect.StructField, tag string) (string, bool) {
+	value, ok := field.Tag.Lookup(tag)
+	if !ok || value == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(value, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
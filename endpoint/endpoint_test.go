@@ -0,0 +1,223 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/openapi"
+	"github.com/deb-ict/go-router/validation"
+)
+
+type createOrderRequest struct {
+	Id       int    `path:"id" json:"-"`
+	DryRun   bool   `query:"dry_run" json:"-"`
+	Tenant   string `header:"X-Tenant" json:"-"`
+	Product  string `json:"product"`
+	Quantity int    `json:"quantity"`
+}
+
+func (r *createOrderRequest) Validate(ctx validation.Context) {
+	if r.Quantity <= 0 {
+		ctx.AddError("quantity", "must be positive")
+	}
+}
+
+type getOrderRequest struct {
+	Id int `path:"id"`
+}
+
+type orderResponse struct {
+	Id       int    `json:"id"`
+	Product  string `json:"product"`
+	Quantity int    `json:"quantity"`
+	Tenant   string `json:"tenant"`
+}
+
+var errOrderExists = errors.New("order exists")
+
+func createOrder(ctx context.Context, req createOrderRequest) (orderResponse, error) {
+	if req.Id == 0 {
+		return orderResponse{}, errOrderExists
+	}
+	return orderResponse{
+		Id:       req.Id,
+		Product:  req.Product,
+		Quantity: req.Quantity,
+		Tenant:   req.Tenant,
+	}, nil
+}
+
+func Test_Handle(t *testing.T) {
+	r := router.NewRouter()
+	Handle(r, "POST /orders/{id}", createOrder, Status(http.StatusCreated))
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/12", strings.NewReader(`{"product":"book","quantity":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", "acme")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Handle() failed: got %v, expected %v (%s)", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	expected := `{"id":12,"product":"book","quantity":2,"tenant":"acme"}`
+	if body := strings.TrimSpace(rec.Body.String()); body != expected {
+		t.Errorf("Handle() failed: got %s, expected %s", body, expected)
+	}
+}
+
+func Test_Handle_MethodNotAllowed(t *testing.T) {
+	r := router.NewRouter()
+	Handle(r, "POST /orders/{id}", createOrder)
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/12", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Handle() failed: got %v, expected %v", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func Test_Handle_ValidationFailed(t *testing.T) {
+	r := router.NewRouter()
+	r.UseProblemDetails()
+	Handle(r, "POST /orders/{id}", createOrder)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/12", strings.NewReader(`{"product":"book","quantity":0}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Handle() failed: got %v, expected %v", rec.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(rec.Body.String(), "quantity") {
+		t.Errorf("Handle() failed: got %s, expected validation errors for quantity", rec.Body.String())
+	}
+}
+
+func Test_Handle_BindingFailed(t *testing.T) {
+	r := router.NewRouter()
+	Handle(r, "POST /orders/{id}", createOrder)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/abc", strings.NewReader(`{"product":"book","quantity":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Handle() failed: got %v, expected %v", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func Test_Handle_UnsupportedMediaType(t *testing.T) {
+	r := router.NewRouter()
+	Handle(r, "POST /orders/{id}", createOrder)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/12", strings.NewReader("book"))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Handle() failed: got %v, expected %v", rec.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func Test_Handle_MalformedMultipart(t *testing.T) {
+	r := router.NewRouter()
+	Handle(r, "POST /orders/{id}", createOrder)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/12", strings.NewReader("book"))
+	req.Header.Set("Content-Type", "multipart/form-data")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Handle() failed: got %v, expected %v", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func Test_Handle_Error(t *testing.T) {
+	r := router.NewRouter()
+	r.SetErrorHandler(router.NewErrorHandler(router.WithErrorStatus(errOrderExists, http.StatusConflict)))
+	Handle(r, "POST /orders/{id}", createOrder)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders/0", strings.NewReader(`{"product":"book","quantity":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Handle() failed: got %v, expected %v", rec.Code, http.StatusConflict)
+	}
+}
+
+func Test_Handle_PointerRequest(t *testing.T) {
+	r := router.NewRouter()
+	Handle(r, "GET /orders/{id}", func(ctx context.Context, req *getOrderRequest) (orderResponse, error) {
+		return orderResponse{Id: req.Id}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/7", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Handle() failed: got %v, expected %v", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), `"id":7`) {
+		t.Errorf("Handle() failed: got %s, expected id 7", rec.Body.String())
+	}
+}
+
+func Test_Handle_NoContent(t *testing.T) {
+	r := router.NewRouter()
+	Handle(r, "DELETE /orders/{id}", func(ctx context.Context, req struct {
+		Id int `path:"id"`
+	}) (struct{}, error) {
+		return struct{}{}, nil
+	}, Status(http.StatusNoContent))
+
+	req := httptest.NewRequest(http.MethodDelete, "/orders/7", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Handle() failed: got %v, expected %v", rec.Code, http.StatusNoContent)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("Handle() failed: got body %s, expected empty body", rec.Body.String())
+	}
+}
+
+func Test_Handle_OperationInfo(t *testing.T) {
+	r := router.NewRouter()
+	route := Handle(r, "POST /orders/{id}", createOrder, Status(http.StatusCreated))
+
+	info, ok := openapi.GetOperationInfo(route)
+	if !ok {
+		t.Fatal("Handle() failed: no operation info")
+	}
+	if len(info.Parameters) != 3 {
+		t.Errorf("Handle() failed: got %v parameters, expected %v", len(info.Parameters), 3)
+	}
+	if info.RequestBody == nil {
+		t.Error("Handle() failed: request body not set")
+	}
+	if len(info.Responses) != 1 || info.Responses[0].Status != http.StatusCreated {
+		t.Errorf("Handle() failed: got %v, expected one %v response", info.Responses, http.StatusCreated)
+	}
+}
+
+func Test_GetRouteStatus(t *testing.T) {
+	if status := GetRouteStatus(nil); status != http.StatusOK {
+		t.Errorf("GetRouteStatus() failed: got %v, expected %v", status, http.StatusOK)
+	}
+}
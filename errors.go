@@ -0,0 +1,142 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+type HandlerErrorFunc func(w http.ResponseWriter, r *http.Request) error
+
+func (f HandlerErrorFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rw := NewResponseWriter(w)
+	if err := f(rw, r); err != nil {
+		HandleError(rw, r, err)
+	}
+}
+
+type ErrorHandler interface {
+	HandleError(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+
+func (f ErrorHandlerFunc) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	f(w, r, err)
+}
+
+type ProblemError interface {
+	error
+	Problem() *Problem
+}
+
+type ErrorHandlerOption func(*DefaultErrorHandler)
+
+type errorStatus struct {
+	err    error
+	status int
+}
+
+type DefaultErrorHandler struct {
+	Logger   *slog.Logger
+	statuses []errorStatus
+}
+
+func NewErrorHandler(opts ...ErrorHandlerOption) *DefaultErrorHandler {
+	h := &DefaultErrorHandler{}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.EnsureDefaults()
+
+	return h
+}
+
+func HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	var node *Node
+	if route := CurrentRoute(r); route != nil {
+		node = route.node
+	}
+	getErrorHandler(node).HandleError(w, r, err)
+}
+
+func (h *DefaultErrorHandler) EnsureDefaults() {
+	if h.Logger == nil {
+		h.Logger = slog.Default()
+	}
+}
+
+func (h *DefaultErrorHandler) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := h.GetProblem(err)
+	h.log(r, problem, err)
+
+	if rw, ok := w.(ResponseWriter); ok && (rw.HeaderWritten() || rw.Hijacked()) {
+		return
+	}
+	if errors.Is(err, context.Canceled) && r.Context().Err() != nil {
+		return
+	}
+	Error(w, r, problem)
+}
+
+func (h *DefaultErrorHandler) GetProblem(err error) *Problem {
+	var problem *Problem
+	if errors.As(err, &problem) {
+		return problem
+	}
+	var problemErr ProblemError
+	if errors.As(err, &problemErr) {
+		return problemErr.Problem()
+	}
+
+	// Wrapping errors can carry internal details, only the message of the matched error is exposed
+	for _, mapping := range h.statuses {
+		if errors.Is(err, mapping.err) {
+			return NewProblem(mapping.status, mapping.err.Error())
+		}
+	}
+
+	var valueErr *ValueError
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &valueErr):
+		return NewProblem(http.StatusBadRequest, valueErr.Error())
+	case errors.Is(err, ErrMissingValue):
+		return NewProblem(http.StatusBadRequest, ErrMissingValue.Error())
+	case errors.Is(err, ErrInvalidValue):
+		return NewProblem(http.StatusBadRequest, ErrInvalidValue.Error())
+	case errors.As(err, &maxBytesErr):
+		return NewProblem(http.StatusRequestEntityTooLarge, "")
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewProblem(http.StatusServiceUnavailable, "")
+	}
+	return NewProblem(http.StatusInternalServerError, "")
+}
+
+func (h *DefaultErrorHandler) log(r *http.Request, problem *Problem, err error) {
+	template := ""
+	if route := CurrentRoute(r); route != nil {
+		template = route.GetTemplate()
+	}
+	level := slog.LevelDebug
+	if problem.Status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	h.Logger.LogAttrs(r.Context(), level, "handler error",
+		slog.String("method", r.Method),
+		slog.String("route", template),
+		slog.Int("status", problem.Status),
+		slog.String("request_id", RequestId(r)),
+		slog.String("error", err.Error()),
+	)
+}
+
+func getErrorHandler(node *Node) ErrorHandler {
+	for ; node != nil; node = node.Parent {
+		if node.Router != nil && node.Router.errorHandler != nil {
+			return node.Router.errorHandler
+		}
+	}
+	return NewErrorHandler()
+}
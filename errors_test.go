@@ -0,0 +1,161 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var errOrderNotFound = errors.New("order not found")
+
+type problemErrorMock struct {
+}
+
+func (e *problemErrorMock) Error() string {
+	return "mock"
+}
+
+func (e *problemErrorMock) Problem() *Problem {
+	return NewProblem(http.StatusUnprocessableEntity, "mock").With("field", "name")
+}
+
+func Test_NewErrorHandler(t *testing.T) {
+	h := NewErrorHandler(WithErrorStatus(errOrderNotFound, http.StatusNotFound))
+
+	if h.Logger == nil {
+		t.Error("NewErrorHandler() failed: default logger not set")
+	}
+	if len(h.statuses) != 1 {
+		t.Errorf("NewErrorHandler() failed: got %v status mappings, expected 1", len(h.statuses))
+	}
+}
+
+func Test_DefaultErrorHandler_GetProblem(t *testing.T) {
+	h := NewErrorHandler(WithErrorStatus(errOrderNotFound, http.StatusNotFound))
+	tests := []struct {
+		err    error
+		status int
+		detail string
+	}{
+		{fmt.Errorf("lookup: %w", errOrderNotFound), http.StatusNotFound, "order not found"},
+		{fmt.Errorf("orders.tenant_7: %w", errOrderNotFound), http.StatusNotFound, "order not found"},
+		{NewProblem(http.StatusConflict, "exists"), http.StatusConflict, "exists"},
+		{fmt.Errorf("wrapped: %w", &problemErrorMock{}), http.StatusUnprocessableEntity, "mock"},
+		{&ValueError{Name: "id", Value: "x", Err: ErrInvalidValue}, http.StatusBadRequest, "id: invalid value \"x\""},
+		{fmt.Errorf("query users.secret: %w", &ValueError{Name: "id", Err: ErrMissingValue}), http.StatusBadRequest, "id: missing value"},
+		{fmt.Errorf("parse config.key: %w", ErrInvalidValue), http.StatusBadRequest, "invalid value"},
+		{&http.MaxBytesError{Limit: 10}, http.StatusRequestEntityTooLarge, ""},
+		{context.DeadlineExceeded, http.StatusServiceUnavailable, ""},
+		{errors.New("database unavailable"), http.StatusInternalServerError, ""},
+	}
+	for _, tt := range tests {
+		problem := h.GetProblem(tt.err)
+		if problem.Status != tt.status || problem.Detail != tt.detail {
+			t.Errorf("GetProblem(%v) failed: got %v/%s, expected %v/%s", tt.err, problem.Status, problem.Detail, tt.status, tt.detail)
+		}
+	}
+}
+
+func Test_Router_HandleErrorFunc(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	router := NewRouter()
+	router.SetErrorHandler(NewErrorHandler(
+		WithErrorLogger(logger),
+		WithErrorStatus(errOrderNotFound, http.StatusNotFound),
+	))
+	if router.GetErrorHandler() == nil {
+		t.Error("Router.SetErrorHandler() failed: error handler not set")
+	}
+	router.HandleErrorFunc("/orders/{id}", func(w http.ResponseWriter, r *http.Request) error {
+		switch Param(r, "id") {
+		case "1":
+			w.Write([]byte("order"))
+			return nil
+		case "2":
+			return errOrderNotFound
+		case "3":
+			w.WriteHeader(http.StatusAccepted)
+			return errors.New("stream failed")
+		}
+		return errors.New("database unavailable")
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/orders/1", http.StatusOK, "order"},
+		{"/orders/2", http.StatusNotFound, "order not found\n"},
+		{"/orders/3", http.StatusAccepted, ""},
+		{"/orders/4", http.StatusInternalServerError, "Internal Server Error\n"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("HandleErrorFunc(%s) failed: got status %v, expected %v", tt.path, rec.Code, tt.status)
+		}
+		if rec.Body.String() != tt.body {
+			t.Errorf("HandleErrorFunc(%s) failed: got body %q, expected %q", tt.path, rec.Body.String(), tt.body)
+		}
+	}
+
+	output := logs.String()
+	if !strings.Contains(output, "route=/orders/{id}") || !strings.Contains(output, "error=\"database unavailable\"") {
+		t.Errorf("HandleErrorFunc() failed: unexpected log output %s", output)
+	}
+}
+
+func Test_Route_HandleErrorFunc_ProblemDetails(t *testing.T) {
+	router := NewRouter().UseProblemDetails()
+	router.PathPrefix("/orders").HandleErrorFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return &problemErrorMock{}
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("HandleErrorFunc() failed: got status %v, expected %v", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if rec.Header().Get("Content-Type") != ProblemContentType {
+		t.Errorf("HandleErrorFunc() failed: got content type %s, expected %s", rec.Header().Get("Content-Type"), ProblemContentType)
+	}
+	if !strings.Contains(rec.Body.String(), "\"field\":\"name\"") {
+		t.Errorf("HandleErrorFunc() failed: extension member missing in %s", rec.Body.String())
+	}
+}
+
+func Test_HandleError_Default(t *testing.T) {
+	h := ErrorHandlerFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	router := NewRouter()
+	router.SetErrorHandler(h)
+	sub := router.PathPrefix("/api").SubRouter()
+	sub.HandleErrorFunc("/orders", func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("failed")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/orders", nil))
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("HandleError(subrouter) failed: got status %v, expected %v", rec.Code, http.StatusTeapot)
+	}
+
+	rec = httptest.NewRecorder()
+	HandleError(rec, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("failed"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("HandleError(no route) failed: got status %v, expected %v", rec.Code, http.StatusInternalServerError)
+	}
+}
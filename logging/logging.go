@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	FieldMethod    string = "method"
+	FieldRoute     string = "route"
+	FieldStatus    string = "status"
+	FieldBytes     string = "bytes"
+	FieldDuration  string = "duration"
+	FieldRemoteIp  string = "remote_ip"
+	FieldRequestId string = "request_id"
+	FieldSubject   string = "subject"
+
+	RedactedValue string = "[REDACTED]"
+)
+
+type Sampler func(r *http.Request, status int) bool
+
+func AlwaysSample(r *http.Request, status int) bool {
+	return true
+}
+
+func RateSampler(rate float64) Sampler {
+	return func(r *http.Request, status int) bool {
+		if status >= http.StatusInternalServerError {
+			return true
+		}
+		if rate >= 1 {
+			return true
+		}
+		if rate <= 0 {
+			return false
+		}
+		return rand.Float64() < rate
+	}
+}
+
+func remoteIp(r *http.Request, headerName string) string {
+	if headerName != "" {
+		if value := r.Header.Get(headerName); value != "" {
+			ip, _, _ := strings.Cut(value, ",")
+			return strings.TrimSpace(ip)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_RateSampler(t *testing.T) {
+	type testCase struct {
+		rate     float64
+		status   int
+		expected bool
+	}
+	tests := []testCase{
+		{0, http.StatusOK, false},
+		{1, http.StatusOK, true},
+		{0, http.StatusInternalServerError, true},
+		{-1, http.StatusBadRequest, false},
+	}
+
+	for _, tc := range tests {
+		sampler := RateSampler(tc.rate)
+		result := sampler(httptest.NewRequest(http.MethodGet, "/", nil), tc.status)
+		if result != tc.expected {
+			t.Errorf("RateSampler(%v)(%v) failed: got %v, expected %v", tc.rate, tc.status, result, tc.expected)
+		}
+	}
+}
+
+func Test_remoteIp(t *testing.T) {
+	type testCase struct {
+		remoteAddr string
+		header     string
+		value      string
+		expected   string
+	}
+	tests := []testCase{
+		{"10.0.0.1:1234", "", "", "10.0.0.1"},
+		{"10.0.0.1", "", "", "10.0.0.1"},
+		{"10.0.0.1:1234", "X-Forwarded-For", "", "10.0.0.1"},
+		{"10.0.0.1:1234", "X-Forwarded-For", "192.168.1.1, 10.0.0.2", "192.168.1.1"},
+	}
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tc.remoteAddr
+		if tc.value != "" {
+			req.Header.Set(tc.header, tc.value)
+		}
+		result := remoteIp(req, tc.header)
+		if result != tc.expected {
+			t.Errorf("remoteIp(%s, %s) failed: got %v, expected %v", tc.remoteAddr, tc.value, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/authentication"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	Logger         *slog.Logger
+	Message        string
+	Sampler        Sampler
+	RedactedFields []string
+	RemoteIpHeader string
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := router.NewResponseWriter(w)
+		next.ServeHTTP(rw, r)
+
+		status := rw.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if !m.Sampler(r, status) {
+			return
+		}
+		m.log(r, status, rw.BytesWritten(), time.Since(start))
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.Logger == nil {
+		m.Logger = slog.Default()
+	}
+	if m.Message == "" {
+		m.Message = "request"
+	}
+	if m.Sampler == nil {
+		m.Sampler = AlwaysSample
+	}
+}
+
+func (m *Middleware) log(r *http.Request, status int, bytes int64, duration time.Duration) {
+	template := ""
+	if route := router.CurrentRoute(r); route != nil {
+		template = route.GetTemplate()
+	}
+	auth := authentication.GetContext(r.Context())
+
+	attrs := []slog.Attr{
+		m.attr(FieldMethod, slog.StringValue(r.Method)),
+		m.attr(FieldRoute, slog.StringValue(template)),
+		m.attr(FieldStatus, slog.IntValue(status)),
+		m.attr(FieldBytes, slog.Int64Value(bytes)),
+		m.attr(FieldDuration, slog.DurationValue(duration)),
+		m.attr(FieldRemoteIp, slog.StringValue(remoteIp(r, m.RemoteIpHeader))),
+		m.attr(FieldRequestId, slog.StringValue(router.RequestId(r))),
+		m.attr(FieldSubject, slog.StringValue(auth.GetSubjectId())),
+	}
+	m.Logger.LogAttrs(r.Context(), m.level(status), m.Message, attrs...)
+}
+
+func (m *Middleware) attr(key string, value slog.Value) slog.Attr {
+	if slices.Contains(m.RedactedFields, key) {
+		return slog.String(key, RedactedValue)
+	}
+	return slog.Attr{Key: key, Value: value}
+}
+
+func (m *Middleware) level(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
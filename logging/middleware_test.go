@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/authentication"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.Logger == nil {
+			t.Error("NewMiddleware() failed: Default logger not set")
+		}
+		if m.Sampler == nil {
+			t.Error("NewMiddleware() failed: Default sampler not set")
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	r := router.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := make(authentication.ClaimMap)
+			claims.AddClaim(authentication.ClaimSubjectId, "user-1")
+			ctx := authentication.SetContext(r.Context(), authentication.NewContext(true, claims))
+			ctx = router.SetRequestId(ctx, "abc")
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	UseMiddleware(r, WithLogger(logger), WithRedactedFields(FieldRemoteIp))
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	})
+
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, httptest.NewRequest(http.MethodPost, "/items/42", nil))
+
+	entry := make(map[string]any)
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("Middleware() failed: log entry not written: %v", err)
+	}
+	expected := map[string]any{
+		FieldMethod:    http.MethodPost,
+		FieldRoute:     "/items/{id}",
+		FieldStatus:    float64(http.StatusCreated),
+		FieldBytes:     float64(5),
+		FieldRemoteIp:  RedactedValue,
+		FieldRequestId: "abc",
+		FieldSubject:   "user-1",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("Middleware() failed: log attribute %s: got %v, expected %v", key, entry[key], value)
+		}
+	}
+	if _, ok := entry[FieldDuration]; !ok {
+		t.Error("Middleware() failed: duration not logged")
+	}
+}
+
+func Test_Middleware_Level(t *testing.T) {
+	type testCase struct {
+		status   int
+		expected string
+	}
+	tests := []testCase{
+		{http.StatusOK, "INFO"},
+		{http.StatusNotFound, "WARN"},
+		{http.StatusInternalServerError, "ERROR"},
+	}
+
+	for _, tc := range tests {
+		var logs bytes.Buffer
+		m := NewMiddleware(WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))))
+		test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.status)
+		}))
+		test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		entry := make(map[string]any)
+		json.Unmarshal(logs.Bytes(), &entry)
+		if entry["level"] != tc.expected {
+			t.Errorf("Middleware(%v) failed: got level %v, expected %v", tc.status, entry["level"], tc.expected)
+		}
+	}
+}
+
+func Test_Middleware_Sampler(t *testing.T) {
+	var logs bytes.Buffer
+	m := NewMiddleware(
+		WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+		WithSampleRate(0),
+	)
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if logs.Len() != 0 {
+		t.Error("Middleware(sampled) failed: request logged, expected skipped")
+	}
+}
+
+func Test_Middleware_PreservesFlusher(t *testing.T) {
+	m := NewMiddleware(WithLogger(slog.New(slog.DiscardHandler)))
+	flusher := false
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, flusher = w.(http.Flusher)
+	}))
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !flusher {
+		t.Error("Middleware() failed: http.Flusher not preserved")
+	}
+}
@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"log/slog"
+)
+
+func WithLogger(logger *slog.Logger) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Logger = logger
+	}
+}
+
+func WithMessage(message string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Message = message
+	}
+}
+
+func WithSampler(sampler Sampler) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Sampler = sampler
+	}
+}
+
+func WithSampleRate(rate float64) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Sampler = RateSampler(rate)
+	}
+}
+
+func WithRedactedFields(fields ...string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.RedactedFields = append(m.RedactedFields, fields...)
+	}
+}
+
+func WithRemoteIpHeader(name string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.RemoteIpHeader = name
+	}
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func Test_WithLogger(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	m := &Middleware{}
+	option := WithLogger(logger)
+	option(m)
+
+	if m.Logger != logger {
+		t.Error("WithLogger() failed: logger not set")
+	}
+}
+
+func Test_WithMessage(t *testing.T) {
+	expected := "test"
+	m := &Middleware{}
+	option := WithMessage(expected)
+	option(m)
+
+	if m.Message != expected {
+		t.Errorf("WithMessage() failed: got %s, expected %s", m.Message, expected)
+	}
+}
+
+func Test_WithSampler(t *testing.T) {
+	m := &Middleware{}
+	option := WithSampler(AlwaysSample)
+	option(m)
+
+	if m.Sampler == nil {
+		t.Error("WithSampler() failed: sampler not set")
+	}
+}
+
+func Test_WithSampleRate(t *testing.T) {
+	m := &Middleware{}
+	option := WithSampleRate(0.5)
+	option(m)
+
+	if m.Sampler == nil {
+		t.Error("WithSampleRate() failed: sampler not set")
+	}
+}
+
+func Test_WithRedactedFields(t *testing.T) {
+	m := &Middleware{}
+	option := WithRedactedFields(FieldRemoteIp, FieldSubject)
+	option(m)
+
+	if len(m.RedactedFields) != 2 {
+		t.Errorf("WithRedactedFields() failed: got %v fields, expected 2", len(m.RedactedFields))
+	}
+}
+
+func Test_WithRemoteIpHeader(t *testing.T) {
+	expected := "X-Forwarded-For"
+	m := &Middleware{}
+	option := WithRemoteIpHeader(expected)
+	option(m)
+
+	if m.RemoteIpHeader != expected {
+		t.Errorf("WithRemoteIpHeader() failed: got %s, expected %s", m.RemoteIpHeader, expected)
+	}
+}
@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ContentType string = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var knownMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+type requestLabels struct {
+	method string
+	route  string
+	status string
+}
+
+type inFlightLabels struct {
+	method string
+	route  string
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+type registry struct {
+	mu        sync.Mutex
+	buckets   []float64
+	requests  map[requestLabels]uint64
+	inFlight  map[inFlightLabels]int64
+	durations map[requestLabels]*histogram
+}
+
+func newRegistry(buckets []float64) *registry {
+	return &registry{
+		buckets:   buckets,
+		requests:  make(map[requestLabels]uint64),
+		inFlight:  make(map[inFlightLabels]int64),
+		durations: make(map[requestLabels]*histogram),
+	}
+}
+
+func (r *registry) begin(method string, route string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight[inFlightLabels{method, route}]++
+}
+
+func (r *registry) end(method string, route string, status int, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.inFlight[inFlightLabels{method, route}]--
+	labels := requestLabels{method, route, strconv.Itoa(status)}
+	r.requests[labels]++
+
+	h, ok := r.durations[labels]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		r.durations[labels] = h
+	}
+	seconds := duration.Seconds()
+	for i, bound := range r.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+func (r *registry) write(w io.Writer, namespace string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var sb strings.Builder
+	requestsName := metricName(namespace, "http_requests_total")
+	fmt.Fprintf(&sb, "# HELP %s Total number of HTTP requests.\n", requestsName)
+	fmt.Fprintf(&sb, "# TYPE %s counter\n", requestsName)
+	for _, labels := range sortedRequestLabels(r.requests) {
+		fmt.Fprintf(&sb, "%s{%s} %d\n", requestsName, labels.String(), r.requests[labels])
+	}
+
+	inFlightName := metricName(namespace, "http_requests_in_flight")
+	fmt.Fprintf(&sb, "# HELP %s Number of HTTP requests currently being served.\n", inFlightName)
+	fmt.Fprintf(&sb, "# TYPE %s gauge\n", inFlightName)
+	inFlight := make([]inFlightLabels, 0, len(r.inFlight))
+	for labels := range r.inFlight {
+		inFlight = append(inFlight, labels)
+	}
+	slices.SortFunc(inFlight, func(a, b inFlightLabels) int {
+		return strings.Compare(a.route+" "+a.method, b.route+" "+b.method)
+	})
+	for _, labels := range inFlight {
+		fmt.Fprintf(&sb, "%s{%s} %d\n", inFlightName, labels.String(), r.inFlight[labels])
+	}
+
+	durationName := metricName(namespace, "http_request_duration_seconds")
+	fmt.Fprintf(&sb, "# HELP %s Duration of HTTP requests in seconds.\n", durationName)
+	fmt.Fprintf(&sb, "# TYPE %s histogram\n", durationName)
+	for _, labels := range sortedRequestLabels(r.durations) {
+		h := r.durations[labels]
+		for i, bound := range r.buckets {
+			fmt.Fprintf(&sb, "%s_bucket{%s,le=\"%s\"} %d\n", durationName, labels.String(), formatFloat(bound), h.counts[i])
+		}
+		fmt.Fprintf(&sb, "%s_bucket{%s,le=\"+Inf\"} %d\n", durationName, labels.String(), h.count)
+		fmt.Fprintf(&sb, "%s_sum{%s} %s\n", durationName, labels.String(), formatFloat(h.sum))
+		fmt.Fprintf(&sb, "%s_count{%s} %d\n", durationName, labels.String(), h.count)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (l requestLabels) String() string {
+	return fmt.Sprintf(`method="%s",route="%s",status="%s"`, escapeLabel(l.method), escapeLabel(l.route), escapeLabel(l.status))
+}
+
+func (l inFlightLabels) String() string {
+	return fmt.Sprintf(`method="%s",route="%s"`, escapeLabel(l.method), escapeLabel(l.route))
+}
+
+func sortedRequestLabels[V any](values map[requestLabels]V) []requestLabels {
+	keys := make([]requestLabels, 0, len(values))
+	for labels := range values {
+		keys = append(keys, labels)
+	}
+	slices.SortFunc(keys, func(a, b requestLabels) int {
+		return strings.Compare(a.route+" "+a.method+" "+a.status, b.route+" "+b.method+" "+b.status)
+	})
+	return keys
+}
+
+func metricName(namespace string, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "_" + name
+}
+
+func escapeLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func methodLabel(method string) string {
+	// Clients choose the method, unknown ones share a label so they can not grow the series without bound
+	if slices.Contains(knownMethods, method) {
+		return method
+	}
+	return "other"
+}
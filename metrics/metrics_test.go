@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_registry_write(t *testing.T) {
+	r := newRegistry([]float64{0.1, 1})
+	r.begin("GET", "/items/{id}")
+	r.end("GET", "/items/{id}", 200, 50*time.Millisecond)
+	r.begin("GET", "/items/{id}")
+	r.end("GET", "/items/{id}", 200, 500*time.Millisecond)
+	r.begin("POST", "/items")
+
+	var sb strings.Builder
+	if err := r.write(&sb, "app"); err != nil {
+		t.Fatalf("registry.write() failed: %v", err)
+	}
+	output := sb.String()
+
+	expected := []string{
+		"# TYPE app_http_requests_total counter",
+		`app_http_requests_total{method="GET",route="/items/{id}",status="200"} 2`,
+		"# TYPE app_http_requests_in_flight gauge",
+		`app_http_requests_in_flight{method="POST",route="/items"} 1`,
+		`app_http_requests_in_flight{method="GET",route="/items/{id}"} 0`,
+		"# TYPE app_http_request_duration_seconds histogram",
+		`app_http_request_duration_seconds_bucket{method="GET",route="/items/{id}",status="200",le="0.1"} 1`,
+		`app_http_request_duration_seconds_bucket{method="GET",route="/items/{id}",status="200",le="1"} 2`,
+		`app_http_request_duration_seconds_bucket{method="GET",route="/items/{id}",status="200",le="+Inf"} 2`,
+		`app_http_request_duration_seconds_sum{method="GET",route="/items/{id}",status="200"} 0.55`,
+		`app_http_request_duration_seconds_count{method="GET",route="/items/{id}",status="200"} 2`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("registry.write() failed: missing line %q in\n%s", line, output)
+		}
+	}
+}
+
+func Test_escapeLabel(t *testing.T) {
+	result := escapeLabel("a\"b\\c\nd")
+	expected := `a\"b\\c\nd`
+	if result != expected {
+		t.Errorf("escapeLabel() failed: got %v, expected %v", result, expected)
+	}
+}
+
+func Test_metricName(t *testing.T) {
+	if result := metricName("", "name"); result != "name" {
+		t.Errorf("metricName(empty) failed: got %v, expected name", result)
+	}
+	if result := metricName("app", "name"); result != "app_name" {
+		t.Errorf("metricName(app) failed: got %v, expected app_name", result)
+	}
+}
+
+func Test_methodLabel(t *testing.T) {
+	tests := []struct {
+		method   string
+		expected string
+	}{
+		{http.MethodGet, http.MethodGet},
+		{http.MethodDelete, http.MethodDelete},
+		{"PROPFIND", "other"},
+		{"get", "other"},
+	}
+	for _, tt := range tests {
+		if result := methodLabel(tt.method); result != tt.expected {
+			t.Errorf("methodLabel(%s) failed: got %v, expected %v", tt.method, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	Namespace string
+	Buckets   []float64
+	registry  *registry
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := methodLabel(r.Method)
+		template := ""
+		if route := router.CurrentRoute(r); route != nil {
+			template = route.GetTemplate()
+		}
+
+		start := time.Now()
+		m.registry.begin(method, template)
+		rw := router.NewResponseWriter(w)
+		defer func() {
+			status := rw.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if rec := recover(); rec != nil {
+				m.registry.end(method, template, http.StatusInternalServerError, time.Since(start))
+				panic(rec)
+			}
+			m.registry.end(method, template, status, time.Since(start))
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+func (m *Middleware) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		m.registry.write(w, m.Namespace)
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if len(m.Buckets) == 0 {
+		m.Buckets = DefaultBuckets
+	}
+	m.Buckets = slices.Sorted(slices.Values(m.Buckets))
+	if m.registry == nil {
+		m.registry = newRegistry(m.Buckets)
+	}
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o, WithBuckets(1, 0.5))
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.registry == nil {
+			t.Error("NewMiddleware() failed: Registry not initialized")
+		}
+		if len(m.Buckets) != 2 || m.Buckets[0] != 0.5 {
+			t.Errorf("NewMiddleware() failed: got buckets %v, expected sorted [0.5 1]", m.Buckets)
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware(t *testing.T) {
+	m := NewMiddleware(WithNamespace("test"))
+	r := router.NewRouter()
+	r.Use(m.Middleware)
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	r.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	r.Handle("/metrics", m.Handler())
+
+	for _, id := range []string{"1", "2", "3"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/"+id, nil))
+	}
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("RANDOM1", "/items/1", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("RANDOM2", "/items/2", nil))
+	func() {
+		defer func() { recover() }()
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/panic", nil))
+	}()
+
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rsp.Header().Get("Content-Type") != ContentType {
+		t.Errorf("Middleware.Handler() failed: got content type %v, expected %v", rsp.Header().Get("Content-Type"), ContentType)
+	}
+	body := rsp.Body.String()
+	expected := []string{
+		`test_http_requests_total{method="GET",route="/items/{id}",status="404"} 3`,
+		`test_http_requests_total{method="GET",route="/panic",status="500"} 1`,
+		`test_http_requests_in_flight{method="GET",route="/metrics"} 1`,
+		`test_http_requests_total{method="other",route="/items/{id}",status="404"} 2`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("Middleware() failed: missing %q in\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, "RANDOM") {
+		t.Error("Middleware() failed: raw method used as label")
+	}
+	if strings.Contains(body, "/items/1") {
+		t.Error("Middleware() failed: raw path used as label")
+	}
+}
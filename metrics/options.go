@@ -0,0 +1,13 @@
+package metrics
+
+func WithNamespace(namespace string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Namespace = namespace
+	}
+}
+
+func WithBuckets(buckets ...float64) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Buckets = buckets
+	}
+}
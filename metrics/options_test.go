@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"testing"
+)
+
+func Test_WithNamespace(t *testing.T) {
+	expected := "test"
+	m := &Middleware{}
+	option := WithNamespace(expected)
+	option(m)
+
+	if m.Namespace != expected {
+		t.Errorf("WithNamespace() failed: got %s, expected %s", m.Namespace, expected)
+	}
+}
+
+func Test_WithBuckets(t *testing.T) {
+	m := &Middleware{}
+	option := WithBuckets(0.1, 1)
+	option(m)
+
+	if len(m.Buckets) != 2 {
+		t.Errorf("WithBuckets() failed: got %v buckets, expected 2", len(m.Buckets))
+	}
+}
@@ -0,0 +1,26 @@
+package openapi
+
+import (
+	"testing"
+)
+
+func Test_BasicSecurityScheme(t *testing.T) {
+	scheme := BasicSecurityScheme()
+	if scheme.Type != "http" || scheme.Scheme != "basic" {
+		t.Errorf("BasicSecurityScheme() failed: got %s/%s, expected http/basic", scheme.Type, scheme.Scheme)
+	}
+}
+
+func Test_BearerSecurityScheme(t *testing.T) {
+	scheme := BearerSecurityScheme("JWT")
+	if scheme.Type != "http" || scheme.Scheme != "bearer" || scheme.BearerFormat != "JWT" {
+		t.Errorf("BearerSecurityScheme() failed: got %s/%s/%s, expected http/bearer/JWT", scheme.Type, scheme.Scheme, scheme.BearerFormat)
+	}
+}
+
+func Test_ApiKeySecurityScheme(t *testing.T) {
+	scheme := ApiKeySecurityScheme(ParameterInHeader, "X-API-KEY")
+	if scheme.Type != "apiKey" || scheme.In != ParameterInHeader || scheme.Name != "X-API-KEY" {
+		t.Errorf("ApiKeySecurityScheme() failed: got %s/%s/%s, expected apiKey/header/X-API-KEY", scheme.Type, scheme.In, scheme.Name)
+	}
+}
@@ -0,0 +1,385 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/render"
+)
+
+const (
+	DefaultTitle     string = "API"
+	DefaultVersion   string = "1.0.0"
+	DefaultMediaType string = "application/json"
+)
+
+var documentedMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+type GeneratorOption func(*Generator)
+
+type Generator struct {
+	Title           string
+	Description     string
+	Version         string
+	MediaType       string
+	Servers         []Server
+	SecuritySchemes map[string]*SecurityScheme
+	PolicySecurity  map[string][]SecurityRequirement
+}
+
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	g := &Generator{}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.EnsureDefaults()
+
+	return g
+}
+
+func Serve(r *router.Router, path string, opts ...GeneratorOption) *router.Route {
+	g := NewGenerator(opts...)
+	return r.Handle(path, g.Handler(r), Hidden()).AllowedMethods(http.MethodGet, http.MethodHead)
+}
+
+func (g *Generator) EnsureDefaults() {
+	if g.Title == "" {
+		g.Title = DefaultTitle
+	}
+	if g.Version == "" {
+		g.Version = DefaultVersion
+	}
+	if g.MediaType == "" {
+		g.MediaType = DefaultMediaType
+	}
+	if g.SecuritySchemes == nil {
+		g.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	if g.PolicySecurity == nil {
+		g.PolicySecurity = make(map[string][]SecurityRequirement)
+	}
+}
+
+func (g *Generator) Handler(r *router.Router) http.Handler {
+	var once sync.Once
+	var data []byte
+	var err error
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		once.Do(func() {
+			data, err = json.Marshal(g.Generate(r))
+		})
+		if err != nil {
+			router.Error(w, req, router.NewProblem(http.StatusInternalServerError, ""))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusOK)
+		if req.Method != http.MethodHead {
+			w.Write(data)
+		}
+	})
+}
+
+func (g *Generator) Generate(r *router.Router) *Document {
+	registry := NewSchemaRegistry()
+	doc := &Document{
+		OpenApi: Version,
+		Info: Info{
+			Title:       g.Title,
+			Description: g.Description,
+			Version:     g.Version,
+		},
+		Servers: g.Servers,
+		Paths:   make(map[string]PathItem),
+	}
+
+	for _, route := range r.Routes() {
+		info, _ := GetOperationInfo(route)
+		if info == nil {
+			info = &OperationInfo{}
+		}
+		if info.Hidden {
+			continue
+		}
+
+		path := getPath(route)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+
+		methods := route.GetMethods()
+		if len(methods) == 0 {
+			methods = documentedMethods
+		}
+		for _, method := range methods {
+			if method == http.MethodOptions {
+				continue
+			}
+			if method == http.MethodHead && slices.Contains(methods, http.MethodGet) {
+				continue
+			}
+			op := g.buildOperation(route, info, registry)
+			if existing, ok := item[strings.ToLower(method)]; ok {
+				// Routes sharing a path and method (host or version matchers) are documented as one operation
+				op = mergeOperation(existing, op)
+			}
+			item[strings.ToLower(method)] = op
+		}
+	}
+
+	if len(registry.Schemas()) > 0 || len(g.SecuritySchemes) > 0 {
+		doc.Components = &Components{
+			Schemas:         registry.Schemas(),
+			SecuritySchemes: g.SecuritySchemes,
+		}
+	}
+	return doc
+}
+
+func (g *Generator) buildOperation(route *router.Route, info *OperationInfo, registry *SchemaRegistry) *Operation {
+	op := &Operation{
+		OperationId: info.OperationId,
+		Summary:     info.Summary,
+		Description: info.Description,
+		Tags:        info.Tags,
+		Parameters:  g.buildParameters(route, info, registry),
+		Deprecated:  info.Deprecated,
+	}
+
+	if info.RequestBody != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{
+				g.MediaType: {Schema: registry.SchemaFor(info.RequestBody)},
+			},
+		}
+	}
+
+	for _, response := range info.Responses {
+		if op.Responses == nil {
+			op.Responses = make(map[string]*Response)
+		}
+		description := response.Description
+		if description == "" {
+			description = http.StatusText(response.Status)
+		}
+		item := &Response{
+			Description: description,
+		}
+		if response.Value != nil {
+			item.Content = make(map[string]MediaType)
+			for _, mediaType := range g.getResponseMediaTypes(route) {
+				item.Content[mediaType] = MediaType{Schema: registry.SchemaFor(response.Value)}
+			}
+		}
+		op.Responses[strconv.Itoa(response.Status)] = item
+	}
+
+	if route.IsAuthorized() {
+		op.Security = g.getSecurity(route.GetAuthorizationPolicy())
+		if op.Responses == nil {
+			op.Responses = make(map[string]*Response)
+		}
+		for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+			if _, ok := op.Responses[strconv.Itoa(status)]; !ok {
+				op.Responses[strconv.Itoa(status)] = &Response{Description: http.StatusText(status)}
+			}
+		}
+	}
+	return op
+}
+
+func (g *Generator) buildParameters(route *router.Route, info *OperationInfo, registry *SchemaRegistry) []*Parameter {
+	parameters := make([]*Parameter, 0)
+	for node := route.GetNode(); node != nil; node = node.Parent {
+		if node.Type != router.NodeTypeParam && node.Type != router.NodeTypeWildcard {
+			continue
+		}
+		if node.Segment == "" {
+			// Unnamed subtree wildcards are not exposed as path parameters
+			continue
+		}
+		parameters = append(parameters, &Parameter{
+			Name:     getParamName(node),
+			In:       ParameterInPath,
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	slices.Reverse(parameters)
+
+	for _, param := range info.Parameters {
+		parameter := &Parameter{
+			Name:     param.Name,
+			In:       param.In,
+			Required: param.Required || param.In == ParameterInPath,
+			Schema:   registry.SchemaFor(param.Value),
+		}
+		index := slices.IndexFunc(parameters, func(p *Parameter) bool {
+			return p.Name == param.Name && p.In == param.In
+		})
+		if index >= 0 {
+			parameters[index] = parameter
+		} else {
+			parameters = append(parameters, parameter)
+		}
+	}
+	if len(parameters) == 0 {
+		return nil
+	}
+	return parameters
+}
+
+func (g *Generator) getResponseMediaTypes(route *router.Route) []string {
+	if produces, ok := render.GetRouteProduces(route); ok && len(produces) > 0 {
+		return produces
+	}
+	return []string{g.MediaType}
+}
+
+func (g *Generator) getSecurity(policy string) []SecurityRequirement {
+	if requirements, ok := g.PolicySecurity[policy]; ok {
+		return requirements
+	}
+
+	names := make([]string, 0, len(g.SecuritySchemes))
+	for name := range g.SecuritySchemes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	requirements := make([]SecurityRequirement, 0, len(names))
+	for _, name := range names {
+		requirements = append(requirements, SecurityRequirement{name: []string{}})
+	}
+	return requirements
+}
+
+func mergeOperation(op *Operation, other *Operation) *Operation {
+	if op.OperationId == "" {
+		op.OperationId = other.OperationId
+	}
+	if op.Summary == "" {
+		op.Summary = other.Summary
+	}
+	if op.Description == "" {
+		op.Description = other.Description
+	}
+	for _, tag := range other.Tags {
+		if !slices.Contains(op.Tags, tag) {
+			op.Tags = append(op.Tags, tag)
+		}
+	}
+	op.Parameters = mergeParameters(op.Parameters, other.Parameters)
+
+	if op.RequestBody == nil {
+		op.RequestBody = other.RequestBody
+	} else if other.RequestBody != nil {
+		op.RequestBody.Required = op.RequestBody.Required && other.RequestBody.Required
+		op.RequestBody.Content = mergeContent(op.RequestBody.Content, other.RequestBody.Content)
+	}
+
+	for status, response := range other.Responses {
+		if op.Responses == nil {
+			op.Responses = make(map[string]*Response)
+		}
+		existing, ok := op.Responses[status]
+		if !ok {
+			op.Responses[status] = response
+			continue
+		}
+		existing.Content = mergeContent(existing.Content, response.Content)
+	}
+
+	for _, requirement := range other.Security {
+		if !slices.ContainsFunc(op.Security, func(r SecurityRequirement) bool {
+			return reflect.DeepEqual(r, requirement)
+		}) {
+			op.Security = append(op.Security, requirement)
+		}
+	}
+	op.Deprecated = op.Deprecated && other.Deprecated
+	return op
+}
+
+func mergeParameters(parameters []*Parameter, other []*Parameter) []*Parameter {
+	contains := func(list []*Parameter, p *Parameter) bool {
+		return slices.ContainsFunc(list, func(item *Parameter) bool {
+			return item.Name == p.Name && item.In == p.In
+		})
+	}
+	for _, parameter := range parameters {
+		if parameter.In != ParameterInPath && !contains(other, parameter) {
+			parameter.Required = false
+		}
+	}
+	for _, parameter := range other {
+		if contains(parameters, parameter) {
+			continue
+		}
+		if parameter.In != ParameterInPath {
+			parameter.Required = false
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+func mergeContent(content map[string]MediaType, other map[string]MediaType) map[string]MediaType {
+	if content == nil {
+		return other
+	}
+	for mediaType, value := range other {
+		existing, ok := content[mediaType]
+		if !ok || existing.Schema == nil {
+			content[mediaType] = value
+			continue
+		}
+		if value.Schema == nil || reflect.DeepEqual(existing.Schema, value.Schema) {
+			continue
+		}
+		if len(existing.Schema.OneOf) == 0 {
+			existing.Schema = &Schema{OneOf: []*Schema{existing.Schema}}
+			content[mediaType] = existing
+		}
+		if !slices.ContainsFunc(existing.Schema.OneOf, func(s *Schema) bool { return reflect.DeepEqual(s, value.Schema) }) {
+			existing.Schema.OneOf = append(existing.Schema.OneOf, value.Schema)
+		}
+	}
+	return content
+}
+
+func getPath(route *router.Route) string {
+	segments := make([]string, 0)
+	for node := route.GetNode(); node != nil && node.Parent != nil; node = node.Parent {
+		segment := node.Segment
+		if (node.Type == router.NodeTypeParam || node.Type == router.NodeTypeWildcard) && node.Segment != "" {
+			segment = "{" + getParamName(node) + "}"
+		}
+		segments = append(segments, segment)
+	}
+	slices.Reverse(segments)
+	return "/" + strings.Join(segments, "/")
+}
+
+func getParamName(node *router.Node) string {
+	if node.Name == "" {
+		return node.Segment
+	}
+	return node.Name
+}
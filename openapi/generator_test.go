@@ -0,0 +1,207 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/render"
+)
+
+type generatorOrder struct {
+	Id    string `json:"id"`
+	Total int    `json:"total"`
+}
+
+func handlerMock(w http.ResponseWriter, r *http.Request) {}
+
+func Test_NewGenerator(t *testing.T) {
+	g := NewGenerator()
+
+	if g.Title != DefaultTitle {
+		t.Errorf("NewGenerator() failed: got title %s, expected %s", g.Title, DefaultTitle)
+	}
+	if g.Version != DefaultVersion {
+		t.Errorf("NewGenerator() failed: got version %s, expected %s", g.Version, DefaultVersion)
+	}
+	if g.MediaType != DefaultMediaType {
+		t.Errorf("NewGenerator() failed: got media type %s, expected %s", g.MediaType, DefaultMediaType)
+	}
+	if g.SecuritySchemes == nil || g.PolicySecurity == nil {
+		t.Error("NewGenerator() failed: security not initialized")
+	}
+}
+
+func Test_Generator_Generate(t *testing.T) {
+	r := router.NewRouter()
+	r.HandleFunc("/orders", handlerMock, Returns(http.StatusOK, []generatorOrder{})).AllowedMethod(http.MethodGet)
+	r.HandleFunc("/orders", handlerMock, Accepts(generatorOrder{}), Returns(http.StatusCreated, generatorOrder{})).AllowedMethod(http.MethodPost).Authorize("write")
+	r.HandleFunc("/orders/{id}/lines/{line}", handlerMock, PathParam("line", 0)).AllowedMethod(http.MethodGet)
+	r.HandleFunc("GET /files/{path...}", handlerMock)
+	r.HandleFunc("/internal", handlerMock, Hidden())
+
+	g := NewGenerator(
+		WithTitle("Orders"),
+		WithSecurityScheme("apiKey", ApiKeySecurityScheme(ParameterInHeader, "X-API-KEY")),
+		WithSecurityScheme("bearer", BearerSecurityScheme("JWT")),
+	)
+	doc := g.Generate(r)
+
+	if doc.OpenApi != Version {
+		t.Errorf("Generate() failed: got version %s, expected %s", doc.OpenApi, Version)
+	}
+	if doc.Info.Title != "Orders" {
+		t.Errorf("Generate() failed: got title %s, expected %s", doc.Info.Title, "Orders")
+	}
+	if len(doc.Paths) != 3 {
+		t.Errorf("Generate() failed: got %v paths, expected %v", len(doc.Paths), 3)
+	}
+
+	if files := doc.Paths["/files/{path}"]; files["head"] != nil {
+		t.Error("Generate() failed: implicit head operation documented")
+	}
+
+	orders := doc.Paths["/orders"]
+	if orders["get"] == nil || orders["post"] == nil {
+		t.Fatalf("Generate() failed: got operations %v, expected get and post", orders)
+	}
+	if orders["get"].Responses["200"].Content[DefaultMediaType].Schema.Type != "array" {
+		t.Error("Generate() failed: get response schema not an array")
+	}
+	post := orders["post"]
+	if post.RequestBody.Content[DefaultMediaType].Schema.Ref != "#/components/schemas/generatorOrder" {
+		t.Error("Generate() failed: post request body schema not referenced")
+	}
+	if len(post.Security) != 2 {
+		t.Errorf("Generate() failed: got %v security requirements, expected %v", len(post.Security), 2)
+	}
+	if post.Responses["401"] == nil || post.Responses["403"] == nil {
+		t.Error("Generate() failed: authorization responses not added")
+	}
+	if orders["get"].Security != nil {
+		t.Error("Generate() failed: security set on anonymous route")
+	}
+
+	lines := doc.Paths["/orders/{id}/lines/{line}"]["get"]
+	if len(lines.Parameters) != 2 {
+		t.Fatalf("Generate() failed: got %v parameters, expected %v", len(lines.Parameters), 2)
+	}
+	if lines.Parameters[0].Name != "id" || lines.Parameters[0].Schema.Type != "string" {
+		t.Errorf("Generate() failed: got first parameter %v, expected string id", lines.Parameters[0])
+	}
+	if lines.Parameters[1].Name != "line" || lines.Parameters[1].Schema.Type != "integer" {
+		t.Errorf("Generate() failed: got second parameter %v, expected integer line", lines.Parameters[1])
+	}
+
+	if _, ok := doc.Paths["/files/{path}"]; !ok {
+		t.Error("Generate() failed: wildcard path not documented")
+	}
+	if doc.Components == nil || doc.Components.Schemas["generatorOrder"] == nil {
+		t.Error("Generate() failed: component schema not registered")
+	}
+}
+
+func Test_Generator_Generate_ParamCase(t *testing.T) {
+	r := router.NewRouter()
+	r.HandleFunc("/Customers/{customerId}/orders/{ID}", handlerMock).AllowedMethod(http.MethodGet)
+
+	doc := NewGenerator().Generate(r)
+	item, ok := doc.Paths["/customers/{customerId}/orders/{ID}"]
+	if !ok {
+		t.Fatalf("Generate() failed: got paths %v, expected /customers/{customerId}/orders/{ID}", doc.Paths)
+	}
+	parameters := item["get"].Parameters
+	if len(parameters) != 2 || parameters[0].Name != "customerId" || parameters[1].Name != "ID" {
+		t.Errorf("Generate() failed: got parameters %v, expected customerId and ID", parameters)
+	}
+}
+
+func Test_Generator_Generate_Merge(t *testing.T) {
+	type generatorOrderV2 struct {
+		Id string `json:"id"`
+	}
+	r := router.NewRouter()
+	r.HandleFunc("/orders/{id}", handlerMock, Summary("Get an order"), Returns(http.StatusOK, generatorOrder{})).AllowedMethod(http.MethodGet).Match(func(r *http.Request) bool {
+		return r.Header.Get("X-Version") == "1"
+	})
+	r.HandleFunc("/orders/{id}", handlerMock, Returns(http.StatusOK, generatorOrderV2{}), Returns(http.StatusNotFound, nil)).AllowedMethod(http.MethodGet).Match(func(r *http.Request) bool {
+		return r.Header.Get("X-Version") == "2"
+	})
+
+	op := NewGenerator().Generate(r).Paths["/orders/{id}"]["get"]
+	if op.Summary != "Get an order" {
+		t.Errorf("Generate() failed: got summary %s, expected %s", op.Summary, "Get an order")
+	}
+	if op.Responses["404"] == nil {
+		t.Error("Generate() failed: response of the second route not merged")
+	}
+	if schema := op.Responses["200"].Content[DefaultMediaType].Schema; len(schema.OneOf) != 2 {
+		t.Errorf("Generate() failed: got %v response schemas, expected %v", len(schema.OneOf), 2)
+	}
+	if len(op.Parameters) != 1 {
+		t.Errorf("Generate() failed: got %v parameters, expected %v", len(op.Parameters), 1)
+	}
+}
+
+func Test_Generator_Generate_PolicySecurity(t *testing.T) {
+	r := router.NewRouter()
+	r.HandleFunc("/orders", handlerMock).Authorize("read")
+
+	g := NewGenerator(
+		WithSecurityScheme("apiKey", ApiKeySecurityScheme(ParameterInHeader, "X-API-KEY")),
+		WithSecurityScheme("oauth", &SecurityScheme{Type: "oauth2"}),
+		WithPolicySecurity("read", "oauth", "orders.read"),
+	)
+	doc := g.Generate(r)
+
+	security := doc.Paths["/orders"]["get"].Security
+	if len(security) != 1 || len(security[0]["oauth"]) != 1 || security[0]["oauth"][0] != "orders.read" {
+		t.Errorf("Generate() failed: got security %v, expected oauth with orders.read", security)
+	}
+}
+
+func Test_Serve(t *testing.T) {
+	r := router.NewRouter()
+	r.HandleFunc("/orders", handlerMock).AllowedMethod(http.MethodGet)
+	Serve(r, "/openapi.json", WithTitle("Orders"))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Serve() failed: got status %v, expected %v", rec.Code, http.StatusOK)
+	}
+	if rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Serve() failed: got content type %s, expected %s", rec.Header().Get("Content-Type"), "application/json")
+	}
+
+	doc := &Document{}
+	if err := json.Unmarshal(rec.Body.Bytes(), doc); err != nil {
+		t.Fatalf("Serve() failed: %v", err)
+	}
+	if _, ok := doc.Paths["/orders"]; !ok {
+		t.Error("Serve() failed: route not documented")
+	}
+	if _, ok := doc.Paths["/openapi.json"]; ok {
+		t.Error("Serve() failed: document endpoint documented")
+	}
+}
+
+func Test_Generator_Generate_Produces(t *testing.T) {
+	r := router.NewRouter()
+	r.HandleFunc("/orders", handlerMock,
+		Returns(http.StatusOK, []generatorOrder{}),
+		render.Produces(render.ContentTypeJson, render.ContentTypeXml),
+	).AllowedMethod(http.MethodGet)
+	doc := NewGenerator().Generate(r)
+
+	content := doc.Paths["/orders"]["get"].Responses["200"].Content
+	if len(content) != 2 {
+		t.Errorf("Generate() failed: got %v media types, expected %v", len(content), 2)
+	}
+	if _, ok := content[render.ContentTypeXml]; !ok {
+		t.Error("Generate() failed: produced media type not documented")
+	}
+}
@@ -0,0 +1,137 @@
+package openapi
+
+import (
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::openapi"
+
+	ParameterInPath   string = "path"
+	ParameterInQuery  string = "query"
+	ParameterInHeader string = "header"
+	ParameterInCookie string = "cookie"
+)
+
+type ParameterInfo struct {
+	Name     string
+	In       string
+	Value    any
+	Required bool
+}
+
+type ResponseInfo struct {
+	Status      int
+	Description string
+	Value       any
+}
+
+type OperationInfo struct {
+	OperationId string
+	Summary     string
+	Description string
+	Tags        []string
+	Parameters  []ParameterInfo
+	RequestBody any
+	Responses   []ResponseInfo
+	Deprecated  bool
+	Hidden      bool
+}
+
+func OperationId(id string) router.RouteOption {
+	return func(r *router.Route) {
+		getOrCreateOperationInfo(r).OperationId = id
+	}
+}
+
+func Summary(summary string) router.RouteOption {
+	return func(r *router.Route) {
+		getOrCreateOperationInfo(r).Summary = summary
+	}
+}
+
+func Description(description string) router.RouteOption {
+	return func(r *router.Route) {
+		getOrCreateOperationInfo(r).Description = description
+	}
+}
+
+func Tags(tags ...string) router.RouteOption {
+	return func(r *router.Route) {
+		info := getOrCreateOperationInfo(r)
+		info.Tags = append(info.Tags, tags...)
+	}
+}
+
+func PathParam(name string, value any) router.RouteOption {
+	return Param(ParameterInPath, name, value, true)
+}
+
+func QueryParam(name string, value any, required bool) router.RouteOption {
+	return Param(ParameterInQuery, name, value, required)
+}
+
+func HeaderParam(name string, value any, required bool) router.RouteOption {
+	return Param(ParameterInHeader, name, value, required)
+}
+
+func Param(in string, name string, value any, required bool) router.RouteOption {
+	return func(r *router.Route) {
+		info := getOrCreateOperationInfo(r)
+		info.Parameters = append(info.Parameters, ParameterInfo{
+			Name:     name,
+			In:       in,
+			Value:    value,
+			Required: required,
+		})
+	}
+}
+
+func Accepts(value any) router.RouteOption {
+	return func(r *router.Route) {
+		getOrCreateOperationInfo(r).RequestBody = value
+	}
+}
+
+func Returns(status int, value any) router.RouteOption {
+	return func(r *router.Route) {
+		info := getOrCreateOperationInfo(r)
+		info.Responses = append(info.Responses, ResponseInfo{
+			Status: status,
+			Value:  value,
+		})
+	}
+}
+
+func Deprecated() router.RouteOption {
+	return func(r *router.Route) {
+		getOrCreateOperationInfo(r).Deprecated = true
+	}
+}
+
+func Hidden() router.RouteOption {
+	return func(r *router.Route) {
+		getOrCreateOperationInfo(r).Hidden = true
+	}
+}
+
+func GetOperationInfo(route *router.Route) (*OperationInfo, bool) {
+	if route == nil {
+		return nil, false
+	}
+	value, ok := route.GetMetadata(metadataKey)
+	if !ok {
+		return nil, false
+	}
+	info, ok := value.(*OperationInfo)
+	return info, ok
+}
+
+func getOrCreateOperationInfo(route *router.Route) *OperationInfo {
+	info, ok := GetOperationInfo(route)
+	if !ok {
+		info = &OperationInfo{}
+		route.SetMetadata(metadataKey, info)
+	}
+	return info
+}
@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_RouteOptions(t *testing.T) {
+	r := router.NewRouter()
+	route := r.HandleFunc("/orders/{id}", func(w http.ResponseWriter, r *http.Request) {},
+		OperationId("getOrder"),
+		Summary("Get order"),
+		Description("Returns a single order"),
+		Tags("orders", "sales"),
+		PathParam("id", 0),
+		QueryParam("expand", "", false),
+		HeaderParam("X-Tenant", "", true),
+		Accepts(struct{}{}),
+		Returns(http.StatusOK, struct{}{}),
+		Deprecated(),
+	)
+
+	info, ok := GetOperationInfo(route)
+	if !ok {
+		t.Fatal("GetOperationInfo() failed: no operation info")
+	}
+	if info.OperationId != "getOrder" {
+		t.Errorf("OperationId() failed: got %s, expected %s", info.OperationId, "getOrder")
+	}
+	if info.Summary != "Get order" {
+		t.Errorf("Summary() failed: got %s, expected %s", info.Summary, "Get order")
+	}
+	if info.Description != "Returns a single order" {
+		t.Errorf("Description() failed: got %s, expected %s", info.Description, "Returns a single order")
+	}
+	if len(info.Tags) != 2 {
+		t.Errorf("Tags() failed: got %v tags, expected %v", len(info.Tags), 2)
+	}
+	if len(info.Parameters) != 3 {
+		t.Errorf("Param() failed: got %v parameters, expected %v", len(info.Parameters), 3)
+	}
+	if info.RequestBody == nil {
+		t.Error("Accepts() failed: request body not set")
+	}
+	if len(info.Responses) != 1 || info.Responses[0].Status != http.StatusOK {
+		t.Errorf("Returns() failed: got %v, expected one %v response", info.Responses, http.StatusOK)
+	}
+	if !info.Deprecated {
+		t.Error("Deprecated() failed: got false, expected true")
+	}
+	if info.Hidden {
+		t.Error("Hidden() failed: got true, expected false")
+	}
+}
+
+func Test_GetOperationInfo_Missing(t *testing.T) {
+	if _, ok := GetOperationInfo(nil); ok {
+		t.Error("GetOperationInfo(nil) failed: got true, expected false")
+	}
+	if _, ok := GetOperationInfo(&router.Route{}); ok {
+		t.Error("GetOperationInfo(empty) failed: got true, expected false")
+	}
+}
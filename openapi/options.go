@@ -0,0 +1,55 @@
+package openapi
+
+func WithTitle(title string) GeneratorOption {
+	return func(g *Generator) {
+		g.Title = title
+	}
+}
+
+func WithDescription(description string) GeneratorOption {
+	return func(g *Generator) {
+		g.Description = description
+	}
+}
+
+func WithVersion(version string) GeneratorOption {
+	return func(g *Generator) {
+		g.Version = version
+	}
+}
+
+func WithMediaType(mediaType string) GeneratorOption {
+	return func(g *Generator) {
+		g.MediaType = mediaType
+	}
+}
+
+func WithServer(url string, description string) GeneratorOption {
+	return func(g *Generator) {
+		g.Servers = append(g.Servers, Server{
+			Url:         url,
+			Description: description,
+		})
+	}
+}
+
+func WithSecurityScheme(name string, scheme *SecurityScheme) GeneratorOption {
+	return func(g *Generator) {
+		if g.SecuritySchemes == nil {
+			g.SecuritySchemes = make(map[string]*SecurityScheme)
+		}
+		g.SecuritySchemes[name] = scheme
+	}
+}
+
+func WithPolicySecurity(policy string, scheme string, scopes ...string) GeneratorOption {
+	return func(g *Generator) {
+		if g.PolicySecurity == nil {
+			g.PolicySecurity = make(map[string][]SecurityRequirement)
+		}
+		if scopes == nil {
+			scopes = []string{}
+		}
+		g.PolicySecurity[policy] = append(g.PolicySecurity[policy], SecurityRequirement{scheme: scopes})
+	}
+}
@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"testing"
+)
+
+func Test_WithTitle(t *testing.T) {
+	expected := "Orders"
+	g := &Generator{}
+	option := WithTitle(expected)
+	option(g)
+
+	if g.Title != expected {
+		t.Errorf("WithTitle() failed: got %s, expected %s", g.Title, expected)
+	}
+}
+
+func Test_WithDescription(t *testing.T) {
+	expected := "Order management"
+	g := &Generator{}
+	option := WithDescription(expected)
+	option(g)
+
+	if g.Description != expected {
+		t.Errorf("WithDescription() failed: got %s, expected %s", g.Description, expected)
+	}
+}
+
+func Test_WithVersion(t *testing.T) {
+	expected := "2.0.0"
+	g := &Generator{}
+	option := WithVersion(expected)
+	option(g)
+
+	if g.Version != expected {
+		t.Errorf("WithVersion() failed: got %s, expected %s", g.Version, expected)
+	}
+}
+
+func Test_WithMediaType(t *testing.T) {
+	expected := "application/xml"
+	g := &Generator{}
+	option := WithMediaType(expected)
+	option(g)
+
+	if g.MediaType != expected {
+		t.Errorf("WithMediaType() failed: got %s, expected %s", g.MediaType, expected)
+	}
+}
+
+func Test_WithServer(t *testing.T) {
+	expected := "https://api.example.com"
+	g := &Generator{}
+	option := WithServer(expected, "production")
+	option(g)
+
+	if len(g.Servers) != 1 || g.Servers[0].Url != expected {
+		t.Errorf("WithServer() failed: got %v, expected %s", g.Servers, expected)
+	}
+}
+
+func Test_WithSecurityScheme(t *testing.T) {
+	expected := BasicSecurityScheme()
+	g := &Generator{}
+	option := WithSecurityScheme("basic", expected)
+	option(g)
+
+	if g.SecuritySchemes["basic"] != expected {
+		t.Errorf("WithSecurityScheme() failed: got %v, expected %v", g.SecuritySchemes["basic"], expected)
+	}
+}
+
+func Test_WithPolicySecurity(t *testing.T) {
+	g := &Generator{}
+	WithPolicySecurity("read", "apiKey")(g)
+	WithPolicySecurity("read", "oauth", "orders.read")(g)
+
+	requirements := g.PolicySecurity["read"]
+	if len(requirements) != 2 {
+		t.Fatalf("WithPolicySecurity() failed: got %v requirements, expected %v", len(requirements), 2)
+	}
+	if scopes := requirements[0]["apiKey"]; scopes == nil || len(scopes) != 0 {
+		t.Errorf("WithPolicySecurity() failed: got scopes %v, expected empty", scopes)
+	}
+	if scopes := requirements[1]["oauth"]; len(scopes) != 1 {
+		t.Errorf("WithPolicySecurity() failed: got scopes %v, expected [orders.read]", scopes)
+	}
+}
@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+type SchemaRegistry struct {
+	schemas map[string]*Schema
+	types   map[reflect.Type]string
+}
+
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{
+		schemas: make(map[string]*Schema),
+		types:   make(map[reflect.Type]string),
+	}
+}
+
+func (s *SchemaRegistry) Schemas() map[string]*Schema {
+	return s.schemas
+}
+
+func (s *SchemaRegistry) SchemaFor(value any) *Schema {
+	if value == nil {
+		return &Schema{}
+	}
+	if t, ok := value.(reflect.Type); ok {
+		return s.schemaForType(t)
+	}
+	return s.schemaForType(reflect.TypeOf(value))
+}
+
+func (s *SchemaRegistry) schemaForType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if t.Kind() != reflect.Struct && (t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		minimum := float64(0)
+		return &Schema{Type: "integer", Minimum: &minimum}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schemaForType(t.Elem())}
+	case reflect.Struct:
+		return s.structSchema(t)
+	}
+	return &Schema{}
+}
+
+func (s *SchemaRegistry) structSchema(t reflect.Type) *Schema {
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+	if t.Name() == "" {
+		return s.buildStructSchema(t)
+	}
+
+	if name, ok := s.types[t]; ok {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	name := s.getSchemaName(t)
+	s.types[t] = name
+	s.schemas[name] = &Schema{}
+	s.schemas[name] = s.buildStructSchema(t)
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (s *SchemaRegistry) buildStructSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	s.addFields(schema, t)
+	return schema
+}
+
+func (s *SchemaRegistry) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				s.addFields(schema, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.schemaForType(field.Type)
+		if description := field.Tag.Get("description"); description != "" && property.Ref == "" {
+			property.Description = description
+		}
+		schema.Properties[name] = property
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") && field.Type.Kind() != reflect.Pointer {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+func (s *SchemaRegistry) getSchemaName(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.Index(name, "["); i >= 0 && strings.HasSuffix(name, "]") {
+		args := strings.Split(name[i+1:len(name)-1], ",")
+		for j, arg := range args {
+			arg = strings.TrimLeft(arg, "*[]")
+			arg = arg[strings.LastIndex(arg, "/")+1:]
+			args[j] = arg[strings.LastIndex(arg, ".")+1:]
+		}
+		name = name[:i] + "_" + strings.Join(args, "_")
+	}
+
+	candidate := name
+	for i := 2; ; i++ {
+		if _, ok := s.schemas[candidate]; !ok {
+			return candidate
+		}
+		candidate = name + strconv.Itoa(i)
+	}
+}
@@ -0,0 +1,131 @@
+package openapi
+
+import (
+	"net/netip"
+	"slices"
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city,omitempty"`
+}
+
+type schemaBase struct {
+	Id int64 `json:"id"`
+}
+
+type schemaCustomer struct {
+	schemaBase
+	Name      string            `json:"name" description:"Full name"`
+	Email     *string           `json:"email"`
+	Address   schemaAddress     `json:"address"`
+	Tags      []string          `json:"tags,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Created   time.Time         `json:"created"`
+	Ip        netip.Addr        `json:"ip"`
+	Parent    *schemaCustomer   `json:"parent,omitempty"`
+	Ignored   string            `json:"-"`
+	Untagged  bool
+	unexposed string
+}
+
+type schemaPage[T any] struct {
+	Items []T `json:"items"`
+}
+
+func Test_SchemaRegistry_SchemaFor_Primitives(t *testing.T) {
+	tests := []struct {
+		value      any
+		schemaType string
+		format     string
+	}{
+		{true, "boolean", ""},
+		{1, "integer", "int64"},
+		{int32(1), "integer", "int32"},
+		{uint(1), "integer", ""},
+		{float32(1), "number", "float"},
+		{1.5, "number", "double"},
+		{"", "string", ""},
+		{[]byte{}, "string", "byte"},
+		{time.Time{}, "string", "date-time"},
+		{time.Second, "integer", "int64"},
+		{[]int{}, "array", ""},
+		{map[string]int{}, "object", ""},
+		{nil, "", ""},
+	}
+	for _, tt := range tests {
+		schema := NewSchemaRegistry().SchemaFor(tt.value)
+		if schema.Type != tt.schemaType || schema.Format != tt.format {
+			t.Errorf("SchemaFor(%T) failed: got %s/%s, expected %s/%s", tt.value, schema.Type, schema.Format, tt.schemaType, tt.format)
+		}
+	}
+}
+
+func Test_SchemaRegistry_SchemaFor_Struct(t *testing.T) {
+	registry := NewSchemaRegistry()
+	schema := registry.SchemaFor(&schemaCustomer{})
+
+	if schema.Ref != "#/components/schemas/schemaCustomer" {
+		t.Fatalf("SchemaFor() failed: got ref %s, expected %s", schema.Ref, "#/components/schemas/schemaCustomer")
+	}
+	customer := registry.Schemas()["schemaCustomer"]
+	if customer == nil {
+		t.Fatal("SchemaFor() failed: schema not registered")
+	}
+
+	properties := make([]string, 0)
+	for name := range customer.Properties {
+		properties = append(properties, name)
+	}
+	slices.Sort(properties)
+	expected := []string{"Untagged", "address", "created", "email", "id", "ip", "labels", "name", "parent", "tags"}
+	if !slices.Equal(properties, expected) {
+		t.Errorf("SchemaFor() failed: got properties %v, expected %v", properties, expected)
+	}
+
+	required := slices.Clone(customer.Required)
+	slices.Sort(required)
+	expected = []string{"Untagged", "address", "created", "id", "ip", "name"}
+	if !slices.Equal(required, expected) {
+		t.Errorf("SchemaFor() failed: got required %v, expected %v", required, expected)
+	}
+
+	if customer.Properties["name"].Description != "Full name" {
+		t.Errorf("SchemaFor() failed: got description %s, expected %s", customer.Properties["name"].Description, "Full name")
+	}
+	if customer.Properties["ip"].Type != "string" {
+		t.Errorf("SchemaFor() failed: got ip type %s, expected %s", customer.Properties["ip"].Type, "string")
+	}
+	if customer.Properties["parent"].Ref != "#/components/schemas/schemaCustomer" {
+		t.Errorf("SchemaFor() failed: got parent ref %s, expected %s", customer.Properties["parent"].Ref, "#/components/schemas/schemaCustomer")
+	}
+	if _, ok := registry.Schemas()["schemaAddress"]; !ok {
+		t.Error("SchemaFor() failed: nested schema not registered")
+	}
+}
+
+func Test_SchemaRegistry_SchemaFor_Generic(t *testing.T) {
+	registry := NewSchemaRegistry()
+	schema := registry.SchemaFor(schemaPage[schemaAddress]{})
+
+	expected := "#/components/schemas/schemaPage_schemaAddress"
+	if schema.Ref != expected {
+		t.Errorf("SchemaFor() failed: got ref %s, expected %s", schema.Ref, expected)
+	}
+}
+
+func Test_SchemaRegistry_SchemaFor_Anonymous(t *testing.T) {
+	registry := NewSchemaRegistry()
+	schema := registry.SchemaFor(struct {
+		Count int `json:"count"`
+	}{})
+
+	if schema.Ref != "" || schema.Type != "object" {
+		t.Errorf("SchemaFor() failed: got %v, expected inline object", schema)
+	}
+	if len(registry.Schemas()) != 0 {
+		t.Errorf("SchemaFor() failed: got %v registered schemas, expected 0", len(registry.Schemas()))
+	}
+}
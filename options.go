@@ -1,5 +1,9 @@
 package router
 
+import (
+	"log/slog"
+)
+
 func AllowedMethod(method string) RouteOption {
 	return func(r *Route) {
 		r.AllowedMethod(method)
@@ -17,3 +21,27 @@ func Authorized(policyName string) RouteOption {
 		r.Authorize(policyName)
 	}
 }
+
+func Metadata(key MetadataKey, value any) RouteOption {
+	return func(r *Route) {
+		r.SetMetadata(key, value)
+	}
+}
+
+func Matcher(matcher RouteMatcher) RouteOption {
+	return func(r *Route) {
+		r.Match(matcher)
+	}
+}
+
+func WithErrorStatus(err error, status int) ErrorHandlerOption {
+	return func(h *DefaultErrorHandler) {
+		h.statuses = append(h.statuses, errorStatus{err: err, status: status})
+	}
+}
+
+func WithErrorLogger(logger *slog.Logger) ErrorHandlerOption {
+	return func(h *DefaultErrorHandler) {
+		h.Logger = logger
+	}
+}
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"log/slog"
 	"net/http"
 	"testing"
 )
@@ -43,3 +44,45 @@ func Test_Authorized(t *testing.T) {
 		t.Errorf("route.GetAuthorizationPolicy() failed: got %v, expected test", route.GetAuthorizationPolicy())
 	}
 }
+
+func Test_Metadata(t *testing.T) {
+	route := &Route{}
+	option := Metadata("key", "value")
+	option(route)
+
+	value, ok := route.GetMetadata("key")
+	if !ok || value != "value" {
+		t.Errorf("Metadata() option failed: got %v, expected value", value)
+	}
+}
+
+func Test_Matcher(t *testing.T) {
+	route := &Route{}
+	option := Matcher(func(r *http.Request) bool { return false })
+	option(route)
+
+	if len(route.matchers) != 1 {
+		t.Errorf("Matcher() option failed: got %v matchers, expected 1", len(route.matchers))
+	}
+}
+
+func Test_WithErrorStatus(t *testing.T) {
+	h := &DefaultErrorHandler{}
+	option := WithErrorStatus(http.ErrNoCookie, http.StatusUnauthorized)
+	option(h)
+
+	if len(h.statuses) != 1 || h.statuses[0].status != http.StatusUnauthorized {
+		t.Errorf("WithErrorStatus() option failed: got %v, expected one mapping to %v", h.statuses, http.StatusUnauthorized)
+	}
+}
+
+func Test_WithErrorLogger(t *testing.T) {
+	expected := slog.New(slog.DiscardHandler)
+	h := &DefaultErrorHandler{}
+	option := WithErrorLogger(expected)
+	option(h)
+
+	if h.Logger != expected {
+		t.Error("WithErrorLogger() option failed: logger not set")
+	}
+}
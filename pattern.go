@@ -0,0 +1,97 @@
+package router
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	exactMatchSegment string = "{$}"
+	subtreeSegment    string = "{...}"
+)
+
+type routePattern struct {
+	method string
+	host   string
+	path   string
+	exact  bool
+}
+
+func parsePattern(pattern string) (routePattern, bool) {
+	var p routePattern
+
+	pattern = strings.TrimSpace(pattern)
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		p.method = pattern[:i]
+		pattern = strings.TrimLeft(pattern[i+1:], " \t")
+		if !isToken(p.method) {
+			return p, false
+		}
+	}
+
+	i := strings.Index(pattern, "/")
+	if i == -1 {
+		return p, false
+	}
+	p.host = strings.ToLower(pattern[:i])
+	p.path = pattern[i:]
+
+	if j := strings.Index(p.path, exactMatchSegment); j >= 0 {
+		if j+len(exactMatchSegment) != len(p.path) || p.path[j-1] != '/' {
+			return p, false
+		}
+		p.path = p.path[:j]
+		p.exact = true
+	}
+	return p, true
+}
+
+func (p routePattern) isSubtree() bool {
+	return !p.exact && strings.HasSuffix(p.path, "/")
+}
+
+func (p routePattern) treePath(subtree bool) string {
+	if subtree && p.isSubtree() {
+		return p.path + subtreeSegment
+	}
+	return p.path
+}
+
+func (p routePattern) apply(route *Route) {
+	if p.host != "" {
+		route.Host(p.host)
+	}
+	if p.exact {
+		route.trailingSlash = true
+	}
+	if p.method != "" {
+		route.AllowedMethod(p.method)
+		if p.method == http.MethodGet {
+			route.AllowedMethod(http.MethodHead)
+		}
+	}
+}
+
+func requestHost(req *http.Request) string {
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
+
+func isToken(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, c := range value {
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			continue
+		}
+		if !strings.ContainsRune("!#$%&'*+-.^_`|~", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,199 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_parsePattern(t *testing.T) {
+	type testCase struct {
+		pattern  string
+		expected routePattern
+		valid    bool
+	}
+	tests := []testCase{
+		{"/items", routePattern{path: "/items"}, true},
+		{"GET /items/{id}", routePattern{method: "GET", path: "/items/{id}"}, true},
+		{"POST   /items", routePattern{method: "POST", path: "/items"}, true},
+		{"example.com/items", routePattern{host: "example.com", path: "/items"}, true},
+		{"GET Example.com/", routePattern{method: "GET", host: "example.com", path: "/"}, true},
+		{"/items/{$}", routePattern{path: "/items/", exact: true}, true},
+		{"/{$}", routePattern{path: "/", exact: true}, true},
+		{"get /items", routePattern{method: "get", path: "/items"}, true},
+		{"G(T /items", routePattern{}, false},
+		{"/static/{path...}", routePattern{path: "/static/{path...}"}, true},
+		{"/items/{$}/edit", routePattern{}, false},
+		{"/items{$}", routePattern{}, false},
+		{"GET items", routePattern{}, false},
+		{"", routePattern{}, false},
+	}
+
+	for _, tc := range tests {
+		result, ok := parsePattern(tc.pattern)
+		if ok != tc.valid {
+			t.Errorf("parsePattern(%s) failed: got valid %v, expected %v", tc.pattern, ok, tc.valid)
+			continue
+		}
+		if ok && result != tc.expected {
+			t.Errorf("parsePattern(%s) failed: got %+v, expected %+v", tc.pattern, result, tc.expected)
+		}
+	}
+}
+
+func Test_Router_ServeHttp_MethodPattern(t *testing.T) {
+	type testCase struct {
+		method   string
+		path     string
+		expected string
+		status   int
+	}
+	tests := []testCase{
+		{http.MethodGet, "/items/1", "get 1", http.StatusOK},
+		{http.MethodHead, "/items/1", "", http.StatusOK},
+		{http.MethodPost, "/items/1", "post 1", http.StatusOK},
+		{http.MethodDelete, "/items/1", "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/items/", "list", http.StatusOK},
+		{http.MethodGet, "/items", "", http.StatusNotFound},
+		{http.MethodGet, "/static/", "", http.StatusOK},
+		{http.MethodGet, "/assets", "", http.StatusMovedPermanently},
+		{http.MethodGet, "/assets/", "assets:/assets/:", http.StatusOK},
+		{http.MethodGet, "/assets/css/site.css", "assets:/assets/css/site.css:", http.StatusOK},
+		{http.MethodGet, "/assets/app.js", "app", http.StatusOK},
+		{http.MethodGet, "/static/css/site.css", "css/site.css", http.StatusOK},
+	}
+
+	router := NewRouter()
+	router.HandleFunc("GET /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			w.Write([]byte("get " + r.PathValue("id")))
+		}
+	})
+	router.HandleFunc("POST /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("post " + r.PathValue("id")))
+	})
+	router.HandleFunc("GET /items/{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("list"))
+	})
+	router.HandleFunc("/static/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue("path")))
+	})
+	router.HandleFunc("GET /assets/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("assets:" + r.URL.Path + ":" + r.PathValue("path")))
+	})
+	router.HandleFunc("GET /assets/app.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("app"))
+	})
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tc.status {
+			t.Errorf("Router.ServeHTTP(%s %s) failed: got status %v, expected %v", tc.method, tc.path, rec.Code, tc.status)
+		}
+		if rec.Body.String() != tc.expected && tc.status == http.StatusOK {
+			t.Errorf("Router.ServeHTTP(%s %s) failed: got %s, expected %s", tc.method, tc.path, rec.Body.String(), tc.expected)
+		}
+	}
+}
+
+func Test_Router_ServeHttp_HostPattern(t *testing.T) {
+	type testCase struct {
+		host     string
+		expected string
+	}
+	tests := []testCase{
+		{"api.example.com", "api"},
+		{"API.example.com:8080", "api"},
+		{"www.example.com", "default"},
+	}
+
+	router := NewRouter()
+	router.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("default"))
+	})
+	router.HandleFunc("GET api.example.com/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("api"))
+	})
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		req.Host = tc.host
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Body.String() != tc.expected {
+			t.Errorf("Router.ServeHTTP(%s) failed: got %s, expected %s", tc.host, rec.Body.String(), tc.expected)
+		}
+	}
+}
+
+func Test_Router_ServeHttp_SubtreePattern(t *testing.T) {
+	type testCase struct {
+		path     string
+		expected string
+	}
+	tests := []testCase{
+		{"/", "home"},
+		{"/about", "fallback:/about"},
+		{"/docs/intro", "fallback:/docs/intro"},
+		{"/api/items", "items"},
+	}
+
+	router := NewRouter()
+	router.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("home"))
+	})
+	router.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fallback:" + r.URL.Path))
+	})
+	router.HandleFunc("GET /api/items", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("items"))
+	})
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Body.String() != tc.expected {
+			t.Errorf("Router.ServeHTTP(%s) failed: got %s, expected %s", tc.path, rec.Body.String(), tc.expected)
+		}
+	}
+}
+
+func Test_Router_ServeHttp_SubtreeRedirect(t *testing.T) {
+	type testCase struct {
+		path     string
+		status   int
+		location string
+	}
+	tests := []testCase{
+		{"/static", http.StatusMovedPermanently, "/static/"},
+		{"/static?v=1", http.StatusMovedPermanently, "/static/?v=1"},
+		{"/static/", http.StatusOK, ""},
+		{"/static/app.js", http.StatusOK, ""},
+	}
+
+	router := NewRouter()
+	router.HandleFunc("GET /static/", func(w http.ResponseWriter, r *http.Request) {
+		if len(r.PathValue("path")) > 0 || len(Params(r)) > 0 {
+			t.Errorf("Router.ServeHTTP(%s) failed: got undeclared path values", r.URL)
+		}
+	})
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tc.status {
+			t.Errorf("Router.ServeHTTP(%s) failed: got status %v, expected %v", tc.path, rec.Code, tc.status)
+		}
+		if location := rec.Header().Get("Location"); location != tc.location {
+			t.Errorf("Router.ServeHTTP(%s) failed: got location %s, expected %s", tc.path, location, tc.location)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+const (
+	ProblemContentType string = "application/problem+json"
+	ProblemTypeBlank   string = "about:blank"
+)
+
+type ErrorRenderer interface {
+	RenderError(w http.ResponseWriter, r *http.Request, problem *Problem)
+}
+
+type ErrorRendererFunc func(w http.ResponseWriter, r *http.Request, problem *Problem)
+
+func (f ErrorRendererFunc) RenderError(w http.ResponseWriter, r *http.Request, problem *Problem) {
+	f(w, r, problem)
+}
+
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+func NewProblem(status int, detail string) *Problem {
+	title := http.StatusText(status)
+	if detail == title {
+		detail = ""
+	}
+	return &Problem{
+		Type:   ProblemTypeBlank,
+		Title:  title,
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func (p *Problem) With(key string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+	if p.Title != "" {
+		return p.Title
+	}
+	return strconv.Itoa(p.Status)
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+	problemType := p.Type
+	if problemType == "" {
+		problemType = ProblemTypeBlank
+	}
+	members["type"] = problemType
+	if p.Title != "" {
+		members["title"] = p.Title
+	}
+	if p.Status != 0 {
+		members["status"] = p.Status
+	}
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+	return json.Marshal(members)
+}
+
+func Error(w http.ResponseWriter, r *http.Request, problem *Problem) {
+	var node *Node
+	if route := CurrentRoute(r); route != nil {
+		node = route.node
+	}
+	renderError(getErrorRenderer(node), w, r, problem)
+}
+
+func PlainErrorRenderer(w http.ResponseWriter, r *http.Request, problem *Problem) {
+	http.Error(w, problem.Error(), problem.Status)
+}
+
+func ProblemErrorRenderer(w http.ResponseWriter, r *http.Request, problem *Problem) {
+	// The problem may be shared by the caller, so the instance is set on a copy
+	rendered := *problem
+	if rendered.Instance == "" && r != nil && r.URL != nil {
+		rendered.Instance = r.URL.Path
+	}
+	data, err := json.Marshal(&rendered)
+	if err != nil {
+		PlainErrorRenderer(w, r, problem)
+		return
+	}
+
+	header := w.Header()
+	header.Del("Content-Length")
+	header.Set("Content-Type", ProblemContentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	w.Write(append(data, '\n'))
+}
+
+func renderError(renderer ErrorRenderer, w http.ResponseWriter, r *http.Request, problem *Problem) {
+	if renderer == nil {
+		PlainErrorRenderer(w, r, problem)
+		return
+	}
+	renderer.RenderError(w, r, problem)
+}
+
+func getErrorRenderer(node *Node) ErrorRenderer {
+	for ; node != nil; node = node.Parent {
+		if node.Router != nil && node.Router.errorRenderer != nil {
+			return node.Router.errorRenderer
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_NewProblem(t *testing.T) {
+	tests := []struct {
+		status int
+		detail string
+		title  string
+		result string
+	}{
+		{http.StatusNotFound, "", "Not Found", ""},
+		{http.StatusForbidden, "Forbidden", "Forbidden", ""},
+		{http.StatusBadRequest, "Invalid name", "Bad Request", "Invalid name"},
+	}
+	for _, tt := range tests {
+		problem := NewProblem(tt.status, tt.detail)
+		if problem.Type != ProblemTypeBlank {
+			t.Errorf("NewProblem(%v) failed: got type %s, expected %s", tt.status, problem.Type, ProblemTypeBlank)
+		}
+		if problem.Title != tt.title {
+			t.Errorf("NewProblem(%v) failed: got title %s, expected %s", tt.status, problem.Title, tt.title)
+		}
+		if problem.Detail != tt.result {
+			t.Errorf("NewProblem(%v) failed: got detail %s, expected %s", tt.status, problem.Detail, tt.result)
+		}
+	}
+}
+
+func Test_Problem_Error(t *testing.T) {
+	tests := []struct {
+		problem  *Problem
+		expected string
+	}{
+		{NewProblem(http.StatusBadRequest, "Invalid name"), "Invalid name"},
+		{NewProblem(http.StatusNotFound, ""), "Not Found"},
+		{&Problem{Status: 499}, "499"},
+	}
+	for _, tt := range tests {
+		if result := tt.problem.Error(); result != tt.expected {
+			t.Errorf("Problem.Error() failed: got %s, expected %s", result, tt.expected)
+		}
+	}
+}
+
+func Test_Problem_MarshalJSON(t *testing.T) {
+	problem := NewProblem(http.StatusConflict, "Order already exists")
+	problem.Instance = "/orders/1"
+	result := problem.With("order_id", 1).With("status", "ignored")
+	if result != problem {
+		t.Error("Problem.With() failed: result not equals instance")
+	}
+
+	data, err := json.Marshal(problem)
+	if err != nil {
+		t.Fatalf("Problem.MarshalJSON() failed: %v", err)
+	}
+	expected := `{"detail":"Order already exists","instance":"/orders/1","order_id":1,"status":409,"title":"Conflict","type":"about:blank"}`
+	if string(data) != expected {
+		t.Errorf("Problem.MarshalJSON() failed: got %s, expected %s", data, expected)
+	}
+}
+
+func Test_ProblemErrorRenderer(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ProblemErrorRenderer(rec, httptest.NewRequest(http.MethodGet, "/orders", nil), NewProblem(http.StatusNotFound, ""))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("ProblemErrorRenderer() failed: got status %v, expected %v", rec.Code, http.StatusNotFound)
+	}
+	if rec.Header().Get("Content-Type") != ProblemContentType {
+		t.Errorf("ProblemErrorRenderer() failed: got content type %s, expected %s", rec.Header().Get("Content-Type"), ProblemContentType)
+	}
+	expected := `{"instance":"/orders","status":404,"title":"Not Found","type":"about:blank"}` + "\n"
+	if rec.Body.String() != expected {
+		t.Errorf("ProblemErrorRenderer() failed: got %s, expected %s", rec.Body.String(), expected)
+	}
+}
+
+func Test_ProblemErrorRenderer_SharedProblem(t *testing.T) {
+	problem := NewProblem(http.StatusNotFound, "")
+	for _, path := range []string{"/items/1", "/items/2"} {
+		rec := httptest.NewRecorder()
+		ProblemErrorRenderer(rec, httptest.NewRequest(http.MethodGet, path, nil), problem)
+
+		expected := `{"instance":"` + path + `","status":404,"title":"Not Found","type":"about:blank"}` + "\n"
+		if rec.Body.String() != expected {
+			t.Errorf("ProblemErrorRenderer(%s) failed: got %s, expected %s", path, rec.Body.String(), expected)
+		}
+	}
+	if problem.Instance != "" {
+		t.Errorf("ProblemErrorRenderer() failed: problem modified, got instance %s", problem.Instance)
+	}
+}
+
+func Test_PlainErrorRenderer(t *testing.T) {
+	rec := httptest.NewRecorder()
+	PlainErrorRenderer(rec, httptest.NewRequest(http.MethodGet, "/", nil), NewProblem(http.StatusForbidden, ""))
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("PlainErrorRenderer() failed: got status %v, expected %v", rec.Code, http.StatusForbidden)
+	}
+	if rec.Body.String() != "Forbidden\n" {
+		t.Errorf("PlainErrorRenderer() failed: got %s, expected %s", rec.Body.String(), "Forbidden")
+	}
+}
+
+func Test_Error(t *testing.T) {
+	router := NewRouter()
+	router.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		Error(w, r, NewProblem(http.StatusConflict, ""))
+	})
+	sub := router.PathPrefix("/api").SubRouter().UseProblemDetails()
+	sub.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+		Error(w, r, NewProblem(http.StatusConflict, ""))
+	})
+
+	tests := []struct {
+		path        string
+		contentType string
+	}{
+		{"/plain", "text/plain; charset=utf-8"},
+		{"/api/orders", ProblemContentType},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != http.StatusConflict {
+			t.Errorf("Error(%s) failed: got status %v, expected %v", tt.path, rec.Code, http.StatusConflict)
+		}
+		if rec.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("Error(%s) failed: got content type %s, expected %s", tt.path, rec.Header().Get("Content-Type"), tt.contentType)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	Error(rec, httptest.NewRequest(http.MethodGet, "/", nil), NewProblem(http.StatusConflict, ""))
+	if rec.Header().Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Errorf("Error(no route) failed: got content type %s", rec.Header().Get("Content-Type"))
+	}
+}
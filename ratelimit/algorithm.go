@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+type State struct {
+	Tokens        float64
+	LastRefill    time.Time
+	WindowStart   time.Time
+	CurrentCount  int
+	PreviousCount int
+	LastSeen      time.Time
+}
+
+type Algorithm interface {
+	Take(state *State, limit Limit, now time.Time) Result
+}
+
+type tokenBucket struct {
+}
+
+type slidingWindow struct {
+}
+
+func NewTokenBucket() Algorithm {
+	return &tokenBucket{}
+}
+
+func NewSlidingWindow() Algorithm {
+	return &slidingWindow{}
+}
+
+func (a *tokenBucket) Take(state *State, limit Limit, now time.Time) Result {
+	burst := float64(limit.GetBurst())
+	rate := float64(limit.Requests) / limit.Period.Seconds()
+
+	if state.LastRefill.IsZero() {
+		state.Tokens = burst
+	} else if elapsed := now.Sub(state.LastRefill).Seconds(); elapsed > 0 {
+		state.Tokens = math.Min(burst, state.Tokens+elapsed*rate)
+	}
+	state.LastRefill = now
+	state.LastSeen = now
+
+	result := Result{
+		Limit: limit.GetBurst(),
+	}
+	if state.Tokens >= 1 {
+		state.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = durationOf((1 - state.Tokens) / rate)
+	}
+	result.Remaining = int(math.Floor(state.Tokens))
+	result.Reset = durationOf((burst - state.Tokens) / rate)
+	return result
+}
+
+func (a *slidingWindow) Take(state *State, limit Limit, now time.Time) Result {
+	windowStart := now.Truncate(limit.Period)
+	if !state.WindowStart.Equal(windowStart) {
+		if windowStart.Sub(state.WindowStart) == limit.Period {
+			state.PreviousCount = state.CurrentCount
+		} else {
+			state.PreviousCount = 0
+		}
+		state.CurrentCount = 0
+		state.WindowStart = windowStart
+	}
+	state.LastSeen = now
+
+	elapsed := now.Sub(windowStart)
+	weight := 1 - float64(elapsed)/float64(limit.Period)
+	estimated := float64(state.PreviousCount)*weight + float64(state.CurrentCount)
+
+	result := Result{
+		Limit: limit.Requests,
+		Reset: limit.Period - elapsed,
+	}
+	if estimated+1 <= float64(limit.Requests) {
+		state.CurrentCount++
+		estimated++
+		result.Allowed = true
+	} else {
+		result.RetryAfter = a.retryAfter(state, limit, elapsed)
+	}
+	result.Remaining = max(0, int(math.Floor(float64(limit.Requests)-estimated)))
+	return result
+}
+
+func (a *slidingWindow) retryAfter(state *State, limit Limit, elapsed time.Duration) time.Duration {
+	if state.CurrentCount >= limit.Requests || state.PreviousCount == 0 {
+		return limit.Period - elapsed
+	}
+	// Wait until the weighted previous window leaves room for one request.
+	available := float64(limit.Requests - state.CurrentCount - 1)
+	weight := available / float64(state.PreviousCount)
+	wait := time.Duration((1-weight)*float64(limit.Period)) - elapsed
+	if wait <= 0 {
+		return time.Second
+	}
+	return wait
+}
+
+func durationOf(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
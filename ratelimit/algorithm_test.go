@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_TokenBucket_Take(t *testing.T) {
+	algorithm := NewTokenBucket()
+	limit := Limit{Requests: 2, Period: time.Second}
+	state := &State{}
+	now := time.Unix(1000, 0)
+
+	for i := 0; i < 2; i++ {
+		result := algorithm.Take(state, limit, now)
+		if !result.Allowed {
+			t.Errorf("TokenBucket.Take(%d) failed: got denied, expected allowed", i)
+		}
+		if result.Remaining != 1-i {
+			t.Errorf("TokenBucket.Take(%d) failed: got remaining %v, expected %v", i, result.Remaining, 1-i)
+		}
+	}
+
+	result := algorithm.Take(state, limit, now)
+	if result.Allowed {
+		t.Error("TokenBucket.Take(exhausted) failed: got allowed, expected denied")
+	}
+	if result.RetryAfter != 500*time.Millisecond {
+		t.Errorf("TokenBucket.Take(exhausted) failed: got retry after %v, expected 500ms", result.RetryAfter)
+	}
+
+	result = algorithm.Take(state, limit, now.Add(500*time.Millisecond))
+	if !result.Allowed {
+		t.Error("TokenBucket.Take(refilled) failed: got denied, expected allowed")
+	}
+}
+
+func Test_TokenBucket_Burst(t *testing.T) {
+	algorithm := NewTokenBucket()
+	limit := Limit{Requests: 1, Period: time.Second, Burst: 3}
+	state := &State{}
+	now := time.Unix(1000, 0)
+
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if algorithm.Take(state, limit, now).Allowed {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("TokenBucket.Take(burst) failed: got %v allowed, expected 3", allowed)
+	}
+}
+
+func Test_SlidingWindow_Take(t *testing.T) {
+	algorithm := NewSlidingWindow()
+	limit := Limit{Requests: 4, Period: time.Minute}
+	state := &State{}
+	start := time.Unix(6000, 0)
+
+	for i := 0; i < 4; i++ {
+		if !algorithm.Take(state, limit, start).Allowed {
+			t.Errorf("SlidingWindow.Take(%d) failed: got denied, expected allowed", i)
+		}
+	}
+	result := algorithm.Take(state, limit, start)
+	if result.Allowed {
+		t.Error("SlidingWindow.Take(exhausted) failed: got allowed, expected denied")
+	}
+	if result.RetryAfter != time.Minute {
+		t.Errorf("SlidingWindow.Take(exhausted) failed: got retry after %v, expected 1m0s", result.RetryAfter)
+	}
+
+	// Halfway the next window, half of the previous window still counts.
+	next := start.Add(90 * time.Second)
+	allowed := 0
+	for i := 0; i < 4; i++ {
+		if algorithm.Take(state, limit, next).Allowed {
+			allowed++
+		}
+	}
+	if allowed != 2 {
+		t.Errorf("SlidingWindow.Take(next window) failed: got %v allowed, expected 2", allowed)
+	}
+
+	// After an idle window the previous count is discarded.
+	later := start.Add(5 * time.Minute)
+	if result := algorithm.Take(state, limit, later); !result.Allowed || result.Remaining != 3 {
+		t.Errorf("SlidingWindow.Take(idle) failed: got %+v, expected allowed with 3 remaining", result)
+	}
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	Limit                Limit
+	Algorithm            Algorithm
+	Store                Store
+	KeyFunc              KeyFunc
+	LimitExceededHandler http.Handler
+	now                  func() time.Time
+}
+
+func NewMiddleware(limit Limit, opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{
+		Limit: limit,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, limit Limit, opts ...MiddlewareOption) {
+	m := NewMiddleware(limit, opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := m.KeyFunc(r)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		limit := m.Limit
+		if routeLimit, ok := GetRouteLimit(router.CurrentRoute(r)); ok {
+			limit = routeLimit
+			key = key + "|" + RouteKey(r)
+		}
+		if !limit.IsValid() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		result, err := m.Store.Take(r.Context(), key, m.Algorithm, limit, m.now())
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		setHeaders(w, limit, result)
+		if !result.Allowed {
+			m.LimitExceededHandler.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.Algorithm == nil {
+		m.Algorithm = NewTokenBucket()
+	}
+	if m.Store == nil {
+		m.Store = NewMemoryStore(0)
+	}
+	if m.KeyFunc == nil {
+		m.KeyFunc = FirstKey(SubjectKey, ClientIpKey)
+	}
+	if m.LimitExceededHandler == nil {
+		m.LimitExceededHandler = http.HandlerFunc(LimitExceededHandler)
+	}
+	if m.now == nil {
+		m.now = time.Now
+	}
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(PerMinute(10), o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.Algorithm == nil || m.Store == nil || m.KeyFunc == nil || m.LimitExceededHandler == nil {
+			t.Error("NewMiddleware() failed: Defaults not set")
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router, PerMinute(10))
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware(t *testing.T) {
+	now := time.Unix(1000, 0)
+	r := router.NewRouter()
+	m := NewMiddleware(PerMinute(2))
+	m.now = func() time.Time { return now }
+	r.Use(m.Middleware)
+	r.HandleFunc("/items", func(w http.ResponseWriter, r *http.Request) {})
+	r.HandleFunc("/export", func(w http.ResponseWriter, r *http.Request) {}, RouteLimit(PerMinute(1)))
+	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {}, Disabled())
+
+	type testCase struct {
+		path      string
+		expected  int
+		remaining string
+	}
+	tests := []testCase{
+		{"/items", http.StatusOK, "1"},
+		{"/items", http.StatusOK, "0"},
+		{"/items", http.StatusTooManyRequests, "0"},
+		{"/export", http.StatusOK, "0"},
+		{"/export", http.StatusTooManyRequests, "0"},
+		{"/health", http.StatusOK, ""},
+		{"/health", http.StatusOK, ""},
+		{"/health", http.StatusOK, ""},
+	}
+
+	for i, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, req)
+
+		if rsp.Code != tc.expected {
+			t.Errorf("Middleware(%d %s) failed: got status %v, expected %v", i, tc.path, rsp.Code, tc.expected)
+		}
+		if remaining := rsp.Header().Get(HeaderRateLimitRemaining); remaining != tc.remaining {
+			t.Errorf("Middleware(%d %s) failed: got remaining %v, expected %v", i, tc.path, remaining, tc.remaining)
+		}
+		if tc.expected == http.StatusTooManyRequests && rsp.Header().Get(HeaderRetryAfter) == "" {
+			t.Errorf("Middleware(%d %s) failed: Retry-After not set", i, tc.path)
+		}
+	}
+}
+
+func Test_Middleware_NoKey(t *testing.T) {
+	m := NewMiddleware(PerMinute(1), WithKeyFunc(func(r *http.Request) string { return "" }))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i := 0; i < 3; i++ {
+		rsp := httptest.NewRecorder()
+		test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rsp.Code != http.StatusOK {
+			t.Errorf("Middleware(noKey) failed: got status %v, expected %v", rsp.Code, http.StatusOK)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"net/http"
+)
+
+func WithAlgorithm(algorithm Algorithm) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Algorithm = algorithm
+	}
+}
+
+func WithStore(store Store) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Store = store
+	}
+}
+
+func WithKeyFunc(keyFunc KeyFunc) MiddlewareOption {
+	return func(m *Middleware) {
+		m.KeyFunc = keyFunc
+	}
+}
+
+func WithLimitExceededHandler(handler http.Handler) MiddlewareOption {
+	return func(m *Middleware) {
+		m.LimitExceededHandler = handler
+	}
+}
+
+func WithLimitExceededHandlerFunc(handle func(http.ResponseWriter, *http.Request)) MiddlewareOption {
+	return func(m *Middleware) {
+		m.LimitExceededHandler = http.HandlerFunc(handle)
+	}
+}
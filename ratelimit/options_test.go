@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_WithAlgorithm(t *testing.T) {
+	m := &Middleware{}
+	option := WithAlgorithm(NewSlidingWindow())
+	option(m)
+
+	if m.Algorithm == nil {
+		t.Error("WithAlgorithm() failed: algorithm not set")
+	}
+}
+
+func Test_WithStore(t *testing.T) {
+	m := &Middleware{}
+	option := WithStore(NewMemoryStore(time.Minute))
+	option(m)
+
+	if m.Store == nil {
+		t.Error("WithStore() failed: store not set")
+	}
+}
+
+func Test_WithKeyFunc(t *testing.T) {
+	m := &Middleware{}
+	option := WithKeyFunc(ClientIpKey)
+	option(m)
+
+	if m.KeyFunc == nil {
+		t.Error("WithKeyFunc() failed: key function not set")
+	}
+}
+
+func Test_WithLimitExceededHandler(t *testing.T) {
+	m := &Middleware{}
+	option := WithLimitExceededHandler(http.NotFoundHandler())
+	option(m)
+
+	if m.LimitExceededHandler == nil {
+		t.Error("WithLimitExceededHandler() failed: handler not set")
+	}
+}
+
+func Test_WithLimitExceededHandlerFunc(t *testing.T) {
+	m := &Middleware{}
+	option := WithLimitExceededHandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	option(m)
+
+	if m.LimitExceededHandler == nil {
+		t.Error("WithLimitExceededHandlerFunc() failed: handler not set")
+	}
+}
@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/authentication"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::ratelimit"
+
+	HeaderRateLimitLimit     string = "RateLimit-Limit"
+	HeaderRateLimitRemaining string = "RateLimit-Remaining"
+	HeaderRateLimitReset     string = "RateLimit-Reset"
+	HeaderRateLimitPolicy    string = "RateLimit-Policy"
+	HeaderRetryAfter         string = "Retry-After"
+)
+
+type KeyFunc func(r *http.Request) string
+
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+func PerSecond(requests int) Limit {
+	return Limit{Requests: requests, Period: time.Second}
+}
+
+func PerMinute(requests int) Limit {
+	return Limit{Requests: requests, Period: time.Minute}
+}
+
+func PerHour(requests int) Limit {
+	return Limit{Requests: requests, Period: time.Hour}
+}
+
+func (l Limit) IsValid() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+func (l Limit) GetBurst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d;w=%d", l.Requests, int(math.Ceil(l.Period.Seconds())))
+}
+
+func RouteLimit(limit Limit) router.RouteOption {
+	return func(r *router.Route) {
+		r.SetMetadata(metadataKey, limit)
+	}
+}
+
+func Disabled() router.RouteOption {
+	return RouteLimit(Limit{})
+}
+
+func GetRouteLimit(route *router.Route) (Limit, bool) {
+	if route == nil {
+		return Limit{}, false
+	}
+	value, ok := route.GetMetadata(metadataKey)
+	if !ok {
+		return Limit{}, false
+	}
+	limit, ok := value.(Limit)
+	return limit, ok
+}
+
+func SubjectKey(r *http.Request) string {
+	auth := authentication.GetContext(r.Context())
+	if !auth.IsAuthenticated() {
+		return ""
+	}
+	subject := auth.GetSubjectId()
+	if subject == "" {
+		return ""
+	}
+	return "subject:" + subject
+}
+
+func ApiKeyKey(headerName string) KeyFunc {
+	return func(r *http.Request) string {
+		apiKey := r.Header.Get(headerName)
+		if apiKey == "" {
+			return ""
+		}
+		// The key is hashed so the secret is never kept in the store or exposed through it
+		sum := sha256.Sum256([]byte(apiKey))
+		return "apikey:" + hex.EncodeToString(sum[:])
+	}
+}
+
+func ClientIpKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if host == "" {
+		return ""
+	}
+	return "ip:" + host
+}
+
+func RouteKey(r *http.Request) string {
+	route := router.CurrentRoute(r)
+	if route == nil {
+		return ""
+	}
+	return "route:" + r.Method + " " + route.GetTemplate()
+}
+
+func FirstKey(keyFuncs ...KeyFunc) KeyFunc {
+	return func(r *http.Request) string {
+		for _, keyFunc := range keyFuncs {
+			if key := keyFunc(r); key != "" {
+				return key
+			}
+		}
+		return ""
+	}
+}
+
+func LimitExceededHandler(w http.ResponseWriter, r *http.Request) {
+	router.Error(w, r, router.NewProblem(http.StatusTooManyRequests, ""))
+}
+
+func setHeaders(w http.ResponseWriter, limit Limit, result Result) {
+	header := w.Header()
+	header.Set(HeaderRateLimitLimit, fmt.Sprint(result.Limit))
+	header.Set(HeaderRateLimitRemaining, fmt.Sprint(result.Remaining))
+	header.Set(HeaderRateLimitReset, fmt.Sprint(seconds(result.Reset)))
+	header.Set(HeaderRateLimitPolicy, limit.String())
+	if !result.Allowed {
+		header.Set(HeaderRetryAfter, fmt.Sprint(seconds(result.RetryAfter)))
+	}
+}
+
+func seconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/authentication"
+)
+
+func Test_Limit(t *testing.T) {
+	limit := PerMinute(100)
+	if !limit.IsValid() {
+		t.Error("Limit.IsValid() failed: got false, expected true")
+	}
+	if limit.GetBurst() != 100 {
+		t.Errorf("Limit.GetBurst() failed: got %v, expected 100", limit.GetBurst())
+	}
+	if limit.String() != "100;w=60" {
+		t.Errorf("Limit.String() failed: got %v, expected 100;w=60", limit.String())
+	}
+	if (Limit{}).IsValid() {
+		t.Error("Limit.IsValid(empty) failed: got true, expected false")
+	}
+}
+
+func Test_RouteLimit(t *testing.T) {
+	route := &router.Route{}
+	option := RouteLimit(PerSecond(5))
+	option(route)
+
+	limit, ok := GetRouteLimit(route)
+	if !ok || limit.Requests != 5 || limit.Period != time.Second {
+		t.Errorf("RouteLimit() failed: got %+v", limit)
+	}
+	if _, ok := GetRouteLimit(nil); ok {
+		t.Error("GetRouteLimit(nil) failed: got true, expected false")
+	}
+}
+
+func Test_KeyFuncs(t *testing.T) {
+	claims := make(authentication.ClaimMap)
+	claims.AddClaim(authentication.ClaimSubjectId, "user-1")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-API-KEY", "secret")
+
+	if key := SubjectKey(req); key != "" {
+		t.Errorf("SubjectKey(anonymous) failed: got %v, expected <empty>", key)
+	}
+	if key := ClientIpKey(req); key != "ip:10.0.0.1" {
+		t.Errorf("ClientIpKey() failed: got %v, expected ip:10.0.0.1", key)
+	}
+	expectedApiKey := "apikey:2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"
+	if key := ApiKeyKey("X-API-KEY")(req); key != expectedApiKey {
+		t.Errorf("ApiKeyKey() failed: got %v, expected %v", key, expectedApiKey)
+	}
+	if key := RouteKey(req); key != "" {
+		t.Errorf("RouteKey(noRoute) failed: got %v, expected <empty>", key)
+	}
+	if key := FirstKey(SubjectKey, ClientIpKey)(req); key != "ip:10.0.0.1" {
+		t.Errorf("FirstKey(anonymous) failed: got %v, expected ip:10.0.0.1", key)
+	}
+
+	req = req.WithContext(authentication.SetContext(req.Context(), authentication.NewContext(true, claims)))
+	if key := FirstKey(SubjectKey, ClientIpKey)(req); key != "subject:user-1" {
+		t.Errorf("FirstKey(authenticated) failed: got %v, expected subject:user-1", key)
+	}
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Store interface {
+	Take(ctx context.Context, key string, algorithm Algorithm, limit Limit, now time.Time) (Result, error)
+}
+
+type memoryState struct {
+	state     State
+	retention time.Duration
+}
+
+type memoryStore struct {
+	mu            sync.Mutex
+	states        map[string]*memoryState
+	ttl           time.Duration
+	lastSweep     time.Time
+	sweepInterval time.Duration
+}
+
+func NewMemoryStore(ttl time.Duration) Store {
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return &memoryStore{
+		states:        make(map[string]*memoryState),
+		ttl:           ttl,
+		sweepInterval: time.Minute,
+	}
+}
+
+func (s *memoryStore) Take(ctx context.Context, key string, algorithm Algorithm, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	entry, ok := s.states[key]
+	if !ok {
+		entry = &memoryState{}
+		s.states[key] = entry
+	}
+	entry.retention = max(entry.retention, retention(limit))
+	return algorithm.Take(&entry.state, limit, now), nil
+}
+
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, entry := range s.states {
+		if now.Sub(entry.state.LastSeen) > max(s.ttl, entry.retention) {
+			delete(s.states, key)
+		}
+	}
+}
+
+func retention(limit Limit) time.Duration {
+	// The sliding window still weighs the previous window and an empty token bucket needs time to refill,
+	// evicting the state any sooner would hand the key a fresh allowance
+	retention := 2 * limit.Period
+	if limit.Requests > 0 {
+		refill := time.Duration(float64(limit.Period) * float64(limit.GetBurst()) / float64(limit.Requests))
+		retention = max(retention, refill)
+	}
+	return retention
+}
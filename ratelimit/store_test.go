@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_MemoryStore_Take(t *testing.T) {
+	store := NewMemoryStore(time.Minute)
+	limit := Limit{Requests: 1, Period: time.Second}
+	now := time.Unix(1000, 0)
+
+	first, _ := store.Take(context.Background(), "a", NewTokenBucket(), limit, now)
+	second, _ := store.Take(context.Background(), "a", NewTokenBucket(), limit, now)
+	other, _ := store.Take(context.Background(), "b", NewTokenBucket(), limit, now)
+
+	if !first.Allowed || second.Allowed || !other.Allowed {
+		t.Errorf("MemoryStore.Take() failed: got %v, %v, %v, expected true, false, true", first.Allowed, second.Allowed, other.Allowed)
+	}
+}
+
+func Test_MemoryStore_Sweep(t *testing.T) {
+	store := NewMemoryStore(time.Minute).(*memoryStore)
+	limit := Limit{Requests: 1, Period: time.Second}
+	now := time.Unix(1000, 0)
+
+	store.Take(context.Background(), "a", NewTokenBucket(), limit, now)
+	store.Take(context.Background(), "b", NewTokenBucket(), limit, now.Add(2*time.Minute))
+
+	if _, ok := store.states["a"]; ok {
+		t.Error("MemoryStore.sweep() failed: expired state not removed")
+	}
+	if _, ok := store.states["b"]; !ok {
+		t.Error("MemoryStore.sweep() failed: active state removed")
+	}
+}
+
+func Test_MemoryStore_Sweep_Period(t *testing.T) {
+	store := NewMemoryStore(time.Minute).(*memoryStore)
+	limit := Limit{Requests: 2, Period: time.Hour}
+	now := time.Unix(3600, 0)
+
+	store.Take(context.Background(), "a", NewSlidingWindow(), limit, now)
+	store.Take(context.Background(), "a", NewSlidingWindow(), limit, now)
+	store.Take(context.Background(), "b", NewSlidingWindow(), limit, now.Add(70*time.Minute))
+
+	if _, ok := store.states["a"]; !ok {
+		t.Fatal("MemoryStore.sweep() failed: state removed while its previous window still counts")
+	}
+	result, _ := store.Take(context.Background(), "a", NewSlidingWindow(), limit, now.Add(70*time.Minute))
+	if result.Allowed {
+		t.Error("MemoryStore.Take() failed: got allowed, expected the previous window to be weighed")
+	}
+
+	store.Take(context.Background(), "b", NewSlidingWindow(), limit, now.Add(4*time.Hour))
+	if _, ok := store.states["a"]; ok {
+		t.Error("MemoryStore.sweep() failed: expired state not removed")
+	}
+}
+
+func Test_retention(t *testing.T) {
+	type testCase struct {
+		limit    Limit
+		expected time.Duration
+	}
+	tests := []testCase{
+		{Limit{Requests: 10, Period: time.Minute}, 2 * time.Minute},
+		{Limit{Requests: 10, Period: time.Minute, Burst: 50}, 5 * time.Minute},
+		{Limit{Period: time.Minute}, 2 * time.Minute},
+	}
+
+	for _, tc := range tests {
+		result := retention(tc.limit)
+		if result != tc.expected {
+			t.Errorf("retention(%v) failed: got %v, expected %v", tc.limit, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package recovery
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	Logger          *slog.Logger
+	Format          ResponseFormat
+	Message         string
+	ResponseHandler http.Handler
+	DisableStack    bool
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := router.NewResponseWriter(w)
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			m.logPanic(r, rec, debug.Stack())
+			if rw.HeaderWritten() || rw.Hijacked() {
+				// The response is already on its way, abort the connection so it is not mistaken for a complete one
+				panic(http.ErrAbortHandler)
+			}
+			m.ResponseHandler.ServeHTTP(rw, r)
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.Logger == nil {
+		m.Logger = slog.Default()
+	}
+	if m.Message == "" {
+		m.Message = DefaultMessage
+	}
+	if m.ResponseHandler == nil {
+		switch m.Format {
+		case ResponseFormatProblem:
+			m.ResponseHandler = ProblemResponseHandler(m.Message)
+		default:
+			m.ResponseHandler = PlainResponseHandler(m.Message)
+		}
+	}
+}
+
+func (m *Middleware) logPanic(r *http.Request, rec any, stack []byte) {
+	attrs := []slog.Attr{
+		slog.String("panic", fmt.Sprint(rec)),
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+	}
+	if route := router.CurrentRoute(r); route != nil {
+		attrs = append(attrs, slog.String("route", route.GetTemplate()))
+	}
+	if requestId := router.RequestId(r); requestId != "" {
+		attrs = append(attrs, slog.String("request_id", requestId))
+	}
+	if !m.DisableStack {
+		attrs = append(attrs, slog.String("stack", string(stack)))
+	}
+	m.Logger.LogAttrs(r.Context(), slog.LevelError, "panic recovered", attrs...)
+}
@@ -0,0 +1,216 @@
+package recovery
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.Logger == nil {
+			t.Error("NewMiddleware() failed: Default logger not set")
+		}
+		if m.ResponseHandler == nil {
+			t.Error("NewMiddleware() failed: Default response handler not set")
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware_NoPanic(t *testing.T) {
+	m := NewMiddleware()
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rsp.Code != http.StatusNoContent {
+		t.Errorf("Middleware(noPanic) failed: got status %v, expected %v", rsp.Code, http.StatusNoContent)
+	}
+}
+
+func Test_Middleware_Panic(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	r := router.NewRouter()
+	UseMiddleware(r, WithLogger(logger))
+	r.HandleFunc("/api/{id}", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/1", nil)
+	req = req.WithContext(router.SetRequestId(req.Context(), "abc"))
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusInternalServerError {
+		t.Errorf("Middleware(panic) failed: got status %v, expected %v", rsp.Code, http.StatusInternalServerError)
+	}
+	entry := make(map[string]any)
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("Middleware(panic) failed: log entry not written: %v", err)
+	}
+	expected := map[string]string{
+		"panic":      "boom",
+		"route":      "/api/{id}",
+		"request_id": "abc",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("Middleware(panic) failed: log attribute %s: got %v, expected %v", key, entry[key], value)
+		}
+	}
+	if stack, _ := entry["stack"].(string); stack == "" {
+		t.Error("Middleware(panic) failed: stack not logged")
+	}
+}
+
+func Test_Middleware_ProblemResponse(t *testing.T) {
+	m := NewMiddleware(
+		WithLogger(slog.New(slog.DiscardHandler)),
+		WithResponseFormat(ResponseFormatProblem),
+		WithMessage("oops"),
+	)
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	if contentType := rsp.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("Middleware(problem) failed: got content type %v, expected application/problem+json", contentType)
+	}
+	problem := &struct {
+		Status   int    `json:"status"`
+		Detail   string `json:"detail"`
+		Instance string `json:"instance"`
+	}{}
+	if err := json.Unmarshal(rsp.Body.Bytes(), problem); err != nil {
+		t.Fatalf("Middleware(problem) failed: invalid body: %v", err)
+	}
+	if problem.Status != http.StatusInternalServerError || problem.Detail != "oops" || problem.Instance != "/items" {
+		t.Errorf("Middleware(problem) failed: unexpected body %+v", problem)
+	}
+}
+
+func Test_Middleware_CustomResponseHandler(t *testing.T) {
+	m := NewMiddleware(
+		WithLogger(slog.New(slog.DiscardHandler)),
+		WithResponseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+	)
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rsp.Code != http.StatusTeapot {
+		t.Errorf("Middleware(customHandler) failed: got status %v, expected %v", rsp.Code, http.StatusTeapot)
+	}
+}
+
+func Test_Middleware_AbortHandler(t *testing.T) {
+	m := NewMiddleware(WithLogger(slog.New(slog.DiscardHandler)))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("Middleware(abort) failed: got panic %v, expected %v", rec, http.ErrAbortHandler)
+		}
+	}()
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func Test_Middleware_DisableStack(t *testing.T) {
+	var logs bytes.Buffer
+	m := NewMiddleware(
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithStackTrace(false),
+	)
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if strings.Contains(logs.String(), "stack=") {
+		t.Error("Middleware(disableStack) failed: stack logged")
+	}
+}
+
+func Test_Middleware_HeaderWritten(t *testing.T) {
+	m := NewMiddleware(WithLogger(slog.New(slog.DiscardHandler)))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("partial"))
+		panic("boom")
+	}))
+
+	rsp := httptest.NewRecorder()
+	func() {
+		defer func() {
+			if p := recover(); p != http.ErrAbortHandler {
+				t.Errorf("Middleware(headerWritten) failed: got panic %v, expected %v", p, http.ErrAbortHandler)
+			}
+		}()
+		test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	if rsp.Code != http.StatusAccepted {
+		t.Errorf("Middleware(headerWritten) failed: got status %v, expected %v", rsp.Code, http.StatusAccepted)
+	}
+	if rsp.Body.String() != "partial" {
+		t.Errorf("Middleware(headerWritten) failed: got body %v, expected partial", rsp.Body.String())
+	}
+}
+
+func Test_Middleware_PreservesFlusher(t *testing.T) {
+	m := NewMiddleware()
+	flusher := false
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, flusher = w.(http.Flusher)
+	}))
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !flusher {
+		t.Error("Middleware() failed: http.Flusher not preserved")
+	}
+}
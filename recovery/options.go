@@ -0,0 +1,42 @@
+package recovery
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+func WithLogger(logger *slog.Logger) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Logger = logger
+	}
+}
+
+func WithResponseFormat(format ResponseFormat) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Format = format
+	}
+}
+
+func WithMessage(message string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Message = message
+	}
+}
+
+func WithResponseHandler(handler http.Handler) MiddlewareOption {
+	return func(m *Middleware) {
+		m.ResponseHandler = handler
+	}
+}
+
+func WithResponseHandlerFunc(handle func(http.ResponseWriter, *http.Request)) MiddlewareOption {
+	return func(m *Middleware) {
+		m.ResponseHandler = http.HandlerFunc(handle)
+	}
+}
+
+func WithStackTrace(enabled bool) MiddlewareOption {
+	return func(m *Middleware) {
+		m.DisableStack = !enabled
+	}
+}
@@ -0,0 +1,69 @@
+package recovery
+
+import (
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+func Test_WithLogger(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	m := &Middleware{}
+	option := WithLogger(logger)
+	option(m)
+
+	if m.Logger != logger {
+		t.Error("WithLogger() failed: logger not set")
+	}
+}
+
+func Test_WithResponseFormat(t *testing.T) {
+	m := &Middleware{}
+	option := WithResponseFormat(ResponseFormatProblem)
+	option(m)
+
+	if m.Format != ResponseFormatProblem {
+		t.Errorf("WithResponseFormat() failed: got %v, expected %v", m.Format, ResponseFormatProblem)
+	}
+}
+
+func Test_WithMessage(t *testing.T) {
+	expected := "test"
+	m := &Middleware{}
+	option := WithMessage(expected)
+	option(m)
+
+	if m.Message != expected {
+		t.Errorf("WithMessage() failed: got %s, expected %s", m.Message, expected)
+	}
+}
+
+func Test_WithResponseHandler(t *testing.T) {
+	m := &Middleware{}
+	option := WithResponseHandler(http.NotFoundHandler())
+	option(m)
+
+	if m.ResponseHandler == nil {
+		t.Error("WithResponseHandler() failed: handler not set")
+	}
+}
+
+func Test_WithResponseHandlerFunc(t *testing.T) {
+	m := &Middleware{}
+	option := WithResponseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	option(m)
+
+	if m.ResponseHandler == nil {
+		t.Error("WithResponseHandlerFunc() failed: handler not set")
+	}
+}
+
+func Test_WithStackTrace(t *testing.T) {
+	m := &Middleware{}
+	option := WithStackTrace(false)
+	option(m)
+
+	if !m.DisableStack {
+		t.Error("WithStackTrace(false) failed: stack not disabled")
+	}
+}
@@ -0,0 +1,30 @@
+package recovery
+
+import (
+	"net/http"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	DefaultMessage string = "Internal Server Error"
+)
+
+type ResponseFormat int
+
+const (
+	ResponseFormatPlain ResponseFormat = iota
+	ResponseFormatProblem
+)
+
+func PlainResponseHandler(message string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.Error(w, r, router.NewProblem(http.StatusInternalServerError, message))
+	})
+}
+
+func ProblemResponseHandler(message string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ProblemErrorRenderer(w, r, router.NewProblem(http.StatusInternalServerError, message))
+	})
+}
@@ -0,0 +1,79 @@
+package render
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+const (
+	ContentTypeJson string = "application/json"
+	ContentTypeXml  string = "application/xml"
+	ContentTypeText string = "text/plain"
+)
+
+type Encoder interface {
+	ContentType() string
+	Encode(w io.Writer, v any) error
+}
+
+type jsonEncoder struct {
+}
+
+func NewJsonEncoder() Encoder {
+	return &jsonEncoder{}
+}
+
+func (e *jsonEncoder) ContentType() string {
+	return ContentTypeJson
+}
+
+func (e *jsonEncoder) Encode(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+type xmlEncoder struct {
+}
+
+func NewXmlEncoder() Encoder {
+	return &xmlEncoder{}
+}
+
+func (e *xmlEncoder) ContentType() string {
+	return ContentTypeXml
+}
+
+func (e *xmlEncoder) Encode(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(v)
+}
+
+type textEncoder struct {
+}
+
+func NewTextEncoder() Encoder {
+	return &textEncoder{}
+}
+
+func (e *textEncoder) ContentType() string {
+	return ContentTypeText
+}
+
+func (e *textEncoder) Encode(w io.Writer, v any) error {
+	var err error
+	switch value := v.(type) {
+	case nil:
+	case string:
+		_, err = io.WriteString(w, value)
+	case []byte:
+		_, err = w.Write(value)
+	case error:
+		_, err = io.WriteString(w, value.Error())
+	default:
+		_, err = fmt.Fprint(w, value)
+	}
+	return err
+}
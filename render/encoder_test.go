@@ -0,0 +1,69 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type encoderItem struct {
+	Name string `json:"name" xml:"name"`
+}
+
+func Test_JsonEncoder(t *testing.T) {
+	var buffer bytes.Buffer
+	encoder := NewJsonEncoder()
+	if err := encoder.Encode(&buffer, encoderItem{Name: "test"}); err != nil {
+		t.Fatalf("JsonEncoder.Encode() failed: %v", err)
+	}
+
+	expected := "{\"name\":\"test\"}\n"
+	if buffer.String() != expected {
+		t.Errorf("JsonEncoder.Encode() failed: got %s, expected %s", buffer.String(), expected)
+	}
+	if encoder.ContentType() != ContentTypeJson {
+		t.Errorf("JsonEncoder.ContentType() failed: got %s, expected %s", encoder.ContentType(), ContentTypeJson)
+	}
+}
+
+func Test_XmlEncoder(t *testing.T) {
+	var buffer bytes.Buffer
+	encoder := NewXmlEncoder()
+	if err := encoder.Encode(&buffer, encoderItem{Name: "test"}); err != nil {
+		t.Fatalf("XmlEncoder.Encode() failed: %v", err)
+	}
+
+	expected := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<encoderItem><name>test</name></encoderItem>"
+	if buffer.String() != expected {
+		t.Errorf("XmlEncoder.Encode() failed: got %s, expected %s", buffer.String(), expected)
+	}
+	if encoder.ContentType() != ContentTypeXml {
+		t.Errorf("XmlEncoder.ContentType() failed: got %s, expected %s", encoder.ContentType(), ContentTypeXml)
+	}
+}
+
+func Test_TextEncoder(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{nil, ""},
+		{"text", "text"},
+		{[]byte("bytes"), "bytes"},
+		{errors.New("failed"), "failed"},
+		{42, "42"},
+	}
+	encoder := NewTextEncoder()
+	for _, tt := range tests {
+		var buffer bytes.Buffer
+		if err := encoder.Encode(&buffer, tt.value); err != nil {
+			t.Fatalf("TextEncoder.Encode(%v) failed: %v", tt.value, err)
+		}
+		if buffer.String() != tt.expected {
+			t.Errorf("TextEncoder.Encode(%v) failed: got %s, expected %s", tt.value, buffer.String(), tt.expected)
+		}
+	}
+	if encoder.ContentType() != ContentTypeText {
+		t.Errorf("TextEncoder.ContentType() failed: got %s, expected %s", encoder.ContentType(), ContentTypeText)
+	}
+}
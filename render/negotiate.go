@@ -0,0 +1,93 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+)
+
+type mediaRange struct {
+	mainType string
+	subType  string
+	quality  float64
+}
+
+func NegotiateContentType(accept string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+
+	ranges := parseAccept(accept)
+	best := ""
+	bestQuality := 0.0
+	for _, offer := range offers {
+		quality := getQuality(ranges, offer)
+		if quality > bestQuality {
+			best = offer
+			bestQuality = quality
+		}
+	}
+	return best, best != ""
+}
+
+func parseAccept(accept string) []mediaRange {
+	ranges := make([]mediaRange, 0)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		mainType, subType, ok := strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), "/")
+		if !ok || mainType == "" || subType == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "q") {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					parsed = 0
+				}
+				quality = parsed
+			}
+		}
+		ranges = append(ranges, mediaRange{
+			mainType: mainType,
+			subType:  subType,
+			quality:  quality,
+		})
+	}
+	return ranges
+}
+
+func getQuality(ranges []mediaRange, offer string) float64 {
+	mediaType, _, _ := strings.Cut(offer, ";")
+	mainType, subType, _ := strings.Cut(strings.ToLower(strings.TrimSpace(mediaType)), "/")
+
+	quality := 0.0
+	specificity := 0
+	for _, r := range ranges {
+		current := 0
+		switch {
+		case r.mainType == mainType && r.subType == subType:
+			current = 4
+		case r.mainType == mainType && hasSuffix(r.subType, subType):
+			current = 3
+		case r.mainType == mainType && r.subType == "*":
+			current = 2
+		case r.mainType == "*" && r.subType == "*":
+			current = 1
+		}
+		if current > specificity {
+			specificity = current
+			quality = r.quality
+		}
+	}
+	return quality
+}
+
+func hasSuffix(subType string, offer string) bool {
+	i := strings.LastIndex(subType, "+")
+	return i >= 0 && subType[i+1:] == offer
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	"testing"
+)
+
+func Test_NegotiateContentType(t *testing.T) {
+	offers := []string{ContentTypeJson, ContentTypeXml, ContentTypeText}
+	tests := []struct {
+		accept   string
+		offers   []string
+		expected string
+		ok       bool
+	}{
+		{"", offers, ContentTypeJson, true},
+		{"*/*", offers, ContentTypeJson, true},
+		{"application/xml", offers, ContentTypeXml, true},
+		{"text/*", offers, ContentTypeText, true},
+		{"application/json;q=0.5, application/xml", offers, ContentTypeXml, true},
+		{"application/*;q=0.8, application/json;q=0", offers, ContentTypeXml, true},
+		{"text/html, */*;q=0.1", offers, ContentTypeJson, true},
+		{"application/vnd.x+json;version=2", offers, ContentTypeJson, true},
+		{"image/png", offers, "", false},
+		{"application/json;q=0", offers, "", false},
+		{"text/plain", nil, "", false},
+	}
+	for _, tt := range tests {
+		result, ok := NegotiateContentType(tt.accept, tt.offers)
+		if result != tt.expected || ok != tt.ok {
+			t.Errorf("NegotiateContentType(%s) failed: got %s/%v, expected %s/%v", tt.accept, result, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func Test_parseAccept(t *testing.T) {
+	ranges := parseAccept("text/html;level=1;q=0.7, invalid, application/json")
+	if len(ranges) != 2 {
+		t.Fatalf("parseAccept() failed: got %v ranges, expected %v", len(ranges), 2)
+	}
+	if ranges[0].mainType != "text" || ranges[0].subType != "html" || ranges[0].quality != 0.7 {
+		t.Errorf("parseAccept() failed: got %v, expected text/html with q=0.7", ranges[0])
+	}
+	if ranges[1].quality != 1 {
+		t.Errorf("parseAccept() failed: got quality %v, expected %v", ranges[1].quality, 1)
+	}
+}
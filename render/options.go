@@ -0,0 +1,23 @@
+package render
+
+import (
+	"net/http"
+)
+
+func WithEncoder(encoder Encoder) RendererOption {
+	return func(rd *Renderer) {
+		rd.Encoders = append(rd.Encoders, encoder)
+	}
+}
+
+func WithEncoders(encoders ...Encoder) RendererOption {
+	return func(rd *Renderer) {
+		rd.Encoders = encoders
+	}
+}
+
+func WithNotAcceptableHandler(handler http.Handler) RendererOption {
+	return func(rd *Renderer) {
+		rd.NotAcceptableHandler = handler
+	}
+}
@@ -0,0 +1,38 @@
+package render
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_WithEncoder(t *testing.T) {
+	rd := &Renderer{}
+	option := WithEncoder(NewTextEncoder())
+	option(rd)
+
+	if len(rd.Encoders) != 1 {
+		t.Errorf("WithEncoder() failed: got %v encoders, expected %v", len(rd.Encoders), 1)
+	}
+}
+
+func Test_WithEncoders(t *testing.T) {
+	rd := NewRenderer(WithEncoders(NewXmlEncoder()))
+
+	if len(rd.Encoders) != 1 || rd.Encoders[0].ContentType() != ContentTypeXml {
+		t.Errorf("WithEncoders() failed: got %v, expected only the xml encoder", rd.Encoders)
+	}
+}
+
+func Test_WithNotAcceptableHandler(t *testing.T) {
+	handlerCalled := 0
+	rd := &Renderer{}
+	option := WithNotAcceptableHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled++
+	}))
+	option(rd)
+
+	rd.NotAcceptableHandler.ServeHTTP(nil, nil)
+	if handlerCalled != 1 {
+		t.Errorf("WithNotAcceptableHandler() failed: handler called %v times, expected 1", handlerCalled)
+	}
+}
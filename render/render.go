@@ -0,0 +1,96 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::produces"
+)
+
+var (
+	ErrNotAcceptable = errors.New("not acceptable")
+
+	DefaultRenderer = NewRenderer()
+)
+
+func Produces(contentTypes ...string) router.RouteOption {
+	return func(r *router.Route) {
+		r.SetMetadata(metadataKey, contentTypes)
+	}
+}
+
+func GetRouteProduces(route *router.Route) ([]string, bool) {
+	if route == nil {
+		return nil, false
+	}
+	value, ok := route.GetMetadata(metadataKey)
+	if !ok {
+		return nil, false
+	}
+	contentTypes, ok := value.([]string)
+	return contentTypes, ok
+}
+
+func Render(w http.ResponseWriter, r *http.Request, status int, v any) error {
+	return DefaultRenderer.Render(w, r, status, v)
+}
+
+func Json(w http.ResponseWriter, status int, v any) error {
+	return Write(w, nil, NewJsonEncoder(), status, v)
+}
+
+func Xml(w http.ResponseWriter, status int, v any) error {
+	return Write(w, nil, NewXmlEncoder(), status, v)
+}
+
+func Text(w http.ResponseWriter, status int, v any) error {
+	return Write(w, nil, NewTextEncoder(), status, v)
+}
+
+func Write(w http.ResponseWriter, r *http.Request, encoder Encoder, status int, v any) error {
+	header := w.Header()
+	if !bodyAllowed(status) {
+		header.Del("Content-Type")
+		header.Del("Content-Length")
+		w.WriteHeader(status)
+		return nil
+	}
+
+	var buffer bytes.Buffer
+	if err := encoder.Encode(&buffer, v); err != nil {
+		return err
+	}
+
+	header.Set("Content-Type", withCharset(encoder.ContentType()))
+	header.Set("Content-Length", strconv.Itoa(buffer.Len()))
+	header.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if r != nil && r.Method == http.MethodHead {
+		return nil
+	}
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+func withCharset(contentType string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || params["charset"] != "" {
+		return contentType
+	}
+	if strings.HasPrefix(mediaType, "text/") || mediaType == ContentTypeJson || mediaType == ContentTypeXml {
+		return contentType + "; charset=utf-8"
+	}
+	return contentType
+}
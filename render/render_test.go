@@ -0,0 +1,124 @@
+package render
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_Produces(t *testing.T) {
+	route := &router.Route{}
+	Produces(ContentTypeJson, ContentTypeText)(route)
+
+	produces, ok := GetRouteProduces(route)
+	if !ok || len(produces) != 2 {
+		t.Errorf("Produces() failed: got %v, expected [%s %s]", produces, ContentTypeJson, ContentTypeText)
+	}
+	if _, ok := GetRouteProduces(nil); ok {
+		t.Error("GetRouteProduces(nil) failed: got true, expected false")
+	}
+}
+
+func Test_Json(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := Json(rec, http.StatusCreated, map[string]int{"id": 1}); err != nil {
+		t.Fatalf("Json() failed: %v", err)
+	}
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Json() failed: got status %v, expected %v", rec.Code, http.StatusCreated)
+	}
+	if rec.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("Json() failed: got content type %s", rec.Header().Get("Content-Type"))
+	}
+	if rec.Header().Get("Content-Length") != "9" {
+		t.Errorf("Json() failed: got content length %s, expected %s", rec.Header().Get("Content-Length"), "9")
+	}
+	if rec.Body.String() != "{\"id\":1}\n" {
+		t.Errorf("Json() failed: got %s", rec.Body.String())
+	}
+}
+
+func Test_Xml(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := Xml(rec, http.StatusOK, encoderItem{Name: "test"}); err != nil {
+		t.Fatalf("Xml() failed: %v", err)
+	}
+	if rec.Header().Get("Content-Type") != "application/xml; charset=utf-8" {
+		t.Errorf("Xml() failed: got content type %s", rec.Header().Get("Content-Type"))
+	}
+}
+
+func Test_Text(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := Text(rec, http.StatusOK, "hello"); err != nil {
+		t.Fatalf("Text() failed: %v", err)
+	}
+	if rec.Header().Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Errorf("Text() failed: got content type %s", rec.Header().Get("Content-Type"))
+	}
+	if rec.Body.String() != "hello" {
+		t.Errorf("Text() failed: got %s, expected %s", rec.Body.String(), "hello")
+	}
+}
+
+func Test_Write_NoBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "text/plain")
+	if err := Write(rec, nil, NewJsonEncoder(), http.StatusNoContent, map[string]int{"id": 1}); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Write() failed: got status %v, expected %v", rec.Code, http.StatusNoContent)
+	}
+	if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+		t.Errorf("Write() failed: got body %s and content type %s, expected none", rec.Body.String(), rec.Header().Get("Content-Type"))
+	}
+}
+
+func Test_Write_Head(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodHead, "/", nil)
+	if err := Write(rec, req, NewTextEncoder(), http.StatusOK, "hello"); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	if rec.Body.Len() != 0 {
+		t.Errorf("Write() failed: got body %s, expected none", rec.Body.String())
+	}
+	if rec.Header().Get("Content-Length") != "5" {
+		t.Errorf("Write() failed: got content length %s, expected %s", rec.Header().Get("Content-Length"), "5")
+	}
+}
+
+func Test_Write_EncodeError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := Write(rec, nil, NewJsonEncoder(), http.StatusOK, make(chan int))
+	if err == nil {
+		t.Fatal("Write() failed: expected error")
+	}
+	if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+		t.Error("Write() failed: response written on encoding error")
+	}
+}
+
+func Test_withCharset(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    string
+	}{
+		{"application/json", "application/json; charset=utf-8"},
+		{"text/csv", "text/csv; charset=utf-8"},
+		{"text/plain; charset=iso-8859-1", "text/plain; charset=iso-8859-1"},
+		{"application/octet-stream", "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		result := withCharset(tt.contentType)
+		if result != tt.expected {
+			t.Errorf("withCharset(%s) failed: got %s, expected %s", tt.contentType, result, tt.expected)
+		}
+	}
+}
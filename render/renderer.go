@@ -0,0 +1,103 @@
+package render
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+)
+
+type RendererOption func(*Renderer)
+
+type Renderer struct {
+	Encoders             []Encoder
+	NotAcceptableHandler http.Handler
+}
+
+func NewRenderer(opts ...RendererOption) *Renderer {
+	// XML and text can not encode every value JSON can, so they are only offered when added explicitly
+	rd := &Renderer{
+		Encoders: []Encoder{
+			NewJsonEncoder(),
+		},
+	}
+	for _, opt := range opts {
+		opt(rd)
+	}
+	rd.EnsureDefaults()
+
+	return rd
+}
+
+func (rd *Renderer) EnsureDefaults() {
+	if len(rd.Encoders) == 0 {
+		rd.Encoders = []Encoder{
+			NewJsonEncoder(),
+		}
+	}
+	if rd.NotAcceptableHandler == nil {
+		rd.NotAcceptableHandler = http.HandlerFunc(defaultNotAcceptableHandler)
+	}
+}
+
+func (rd *Renderer) Render(w http.ResponseWriter, r *http.Request, status int, v any) error {
+	w.Header().Add("Vary", "Accept")
+
+	encoder, ok := rd.Negotiate(r)
+	if !ok {
+		rd.NotAcceptableHandler.ServeHTTP(w, r)
+		return ErrNotAcceptable
+	}
+	return Write(w, r, encoder, status, v)
+}
+
+func (rd *Renderer) Negotiate(r *http.Request) (Encoder, bool) {
+	encoders := rd.getEncoders(router.CurrentRoute(r))
+
+	offers := make([]string, 0, len(encoders))
+	for _, encoder := range encoders {
+		offers = append(offers, encoder.ContentType())
+	}
+	contentType, ok := NegotiateContentType(strings.Join(r.Header.Values("Accept"), ","), offers)
+	if !ok {
+		return nil, false
+	}
+	for _, encoder := range encoders {
+		if encoder.ContentType() == contentType {
+			return encoder, true
+		}
+	}
+	return nil, false
+}
+
+func (rd *Renderer) getEncoders(route *router.Route) []Encoder {
+	produces, ok := GetRouteProduces(route)
+	if !ok {
+		return rd.Encoders
+	}
+
+	encoders := make([]Encoder, 0, len(produces))
+	for _, contentType := range produces {
+		for _, encoder := range rd.Encoders {
+			if sameMediaType(encoder.ContentType(), contentType) {
+				encoders = append(encoders, encoder)
+				break
+			}
+		}
+	}
+	return encoders
+}
+
+func sameMediaType(a string, b string) bool {
+	mediaTypeA, _, errA := mime.ParseMediaType(a)
+	mediaTypeB, _, errB := mime.ParseMediaType(b)
+	if errA != nil || errB != nil {
+		return strings.EqualFold(a, b)
+	}
+	return mediaTypeA == mediaTypeB
+}
+
+func defaultNotAcceptableHandler(w http.ResponseWriter, r *http.Request) {
+	router.Error(w, r, router.NewProblem(http.StatusNotAcceptable, ""))
+}
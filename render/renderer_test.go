@@ -0,0 +1,128 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+type csvEncoderMock struct {
+}
+
+func (e *csvEncoderMock) ContentType() string {
+	return "text/csv"
+}
+
+func (e *csvEncoderMock) Encode(w io.Writer, v any) error {
+	_, err := fmt.Fprintf(w, "%v", v)
+	return err
+}
+
+func Test_NewRenderer(t *testing.T) {
+	rd := NewRenderer(WithEncoder(&csvEncoderMock{}))
+
+	if len(rd.Encoders) != 2 || rd.Encoders[0].ContentType() != ContentTypeJson {
+		t.Errorf("NewRenderer() failed: got %v encoders, expected json and csv", len(rd.Encoders))
+	}
+	if rd.NotAcceptableHandler == nil {
+		t.Error("NewRenderer() failed: default not acceptable handler not set")
+	}
+}
+
+func Test_Renderer_Render(t *testing.T) {
+	rd := NewRenderer(WithEncoder(NewXmlEncoder()), WithEncoder(&csvEncoderMock{}))
+	tests := []struct {
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"", http.StatusOK, "application/json; charset=utf-8"},
+		{"application/xml", http.StatusOK, "application/xml; charset=utf-8"},
+		{"text/csv, application/json;q=0.9", http.StatusOK, "text/csv; charset=utf-8"},
+		{"image/png", http.StatusNotAcceptable, "text/plain; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		err := rd.Render(rec, req, http.StatusOK, "value")
+
+		if rec.Code != tt.status {
+			t.Errorf("Render(%s) failed: got status %v, expected %v", tt.accept, rec.Code, tt.status)
+		}
+		if rec.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("Render(%s) failed: got content type %s, expected %s", tt.accept, rec.Header().Get("Content-Type"), tt.contentType)
+		}
+		if rec.Header().Get("Vary") != "Accept" {
+			t.Errorf("Render(%s) failed: Vary header not set", tt.accept)
+		}
+		if (tt.status == http.StatusNotAcceptable) != errors.Is(err, ErrNotAcceptable) {
+			t.Errorf("Render(%s) failed: got error %v", tt.accept, err)
+		}
+	}
+}
+
+func Test_Renderer_Render_Produces(t *testing.T) {
+	rd := NewRenderer(WithEncoder(NewTextEncoder()))
+	r := router.NewRouter()
+	r.HandleFunc("/report", func(w http.ResponseWriter, r *http.Request) {
+		rd.Render(w, r, http.StatusOK, "report")
+	}, Produces(ContentTypeText))
+
+	tests := []struct {
+		accept string
+		status int
+	}{
+		{"", http.StatusOK},
+		{"*/*", http.StatusOK},
+		{"text/plain", http.StatusOK},
+		{"application/json", http.StatusNotAcceptable},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/report", nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("Render(%s) failed: got status %v, expected %v", tt.accept, rec.Code, tt.status)
+		}
+		if tt.status == http.StatusOK && rec.Body.String() != "report" {
+			t.Errorf("Render(%s) failed: got %s, expected %s", tt.accept, rec.Body.String(), "report")
+		}
+	}
+}
+
+func Test_Renderer_Render_Defaults(t *testing.T) {
+	tests := []struct {
+		accept string
+		status int
+	}{
+		{"application/json", http.StatusOK},
+		{"application/xml", http.StatusNotAcceptable},
+		{"text/plain", http.StatusNotAcceptable},
+		{"application/xml, */*;q=0.1", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", tt.accept)
+		rec := httptest.NewRecorder()
+		Render(rec, req, http.StatusOK, map[string]int{"count": 1})
+
+		if rec.Code != tt.status {
+			t.Errorf("Render(%s) failed: got status %v, expected %v", tt.accept, rec.Code, tt.status)
+		}
+		if tt.status == http.StatusOK && rec.Body.String() != "{\"count\":1}\n" {
+			t.Errorf("Render(%s) failed: got %s, expected %s", tt.accept, rec.Body.String(), `{"count":1}`)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package requestid
+
+import (
+	"net/http"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	HeaderName    string
+	Generator     Generator
+	IgnoreRequest bool
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := ""
+		if !m.IgnoreRequest {
+			requestId = r.Header.Get(m.HeaderName)
+		}
+		if !isValid(requestId) {
+			requestId = m.Generator()
+		}
+		w.Header().Set(m.HeaderName, requestId)
+
+		ctx := router.SetRequestId(r.Context(), requestId)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.HeaderName == "" {
+		m.HeaderName = DefaultHeaderName
+	}
+	if m.Generator == nil {
+		m.Generator = NewUUID
+	}
+}
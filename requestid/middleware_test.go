@@ -0,0 +1,119 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/authorization"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.HeaderName != DefaultHeaderName {
+			t.Errorf("NewMiddleware() failed: got header name %v, expected %v", m.HeaderName, DefaultHeaderName)
+		}
+		if m.Generator == nil {
+			t.Error("NewMiddleware() failed: Default generator not set")
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware(t *testing.T) {
+	type testCase struct {
+		header   string
+		ignore   bool
+		expected string
+	}
+	tests := []testCase{
+		{"", false, "generated"},
+		{"incoming", false, "incoming"},
+		{"incoming", true, "generated"},
+		{"in valid", false, "generated"},
+	}
+
+	for _, tc := range tests {
+		m := NewMiddleware(
+			WithGenerator(func() string { return "generated" }),
+			WithIgnoreRequestHeader(tc.ignore),
+		)
+		result := ""
+		test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result = router.RequestId(r)
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.header != "" {
+			req.Header.Set(DefaultHeaderName, tc.header)
+		}
+		rsp := httptest.NewRecorder()
+		test.ServeHTTP(rsp, req)
+
+		if result != tc.expected {
+			t.Errorf("Middleware(%s) failed: got context value %v, expected %v", tc.header, result, tc.expected)
+		}
+		if header := rsp.Header().Get(DefaultHeaderName); header != tc.expected {
+			t.Errorf("Middleware(%s) failed: got response header %v, expected %v", tc.header, header, tc.expected)
+		}
+	}
+}
+
+func Test_Middleware_CustomHeader(t *testing.T) {
+	m := NewMiddleware(WithHeaderName("X-Correlation-ID"))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Correlation-ID", "abc")
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, req)
+
+	if header := rsp.Header().Get("X-Correlation-ID"); header != "abc" {
+		t.Errorf("Middleware(customHeader) failed: got %v, expected abc", header)
+	}
+}
+
+func Test_Middleware_AuthorizationFailureHandler(t *testing.T) {
+	result := ""
+	r := router.NewRouter()
+	UseMiddleware(r, WithGenerator(func() string { return "generated" }))
+	authorization.UseMiddleware(r, authorization.WithUnauthorizedHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result = router.RequestId(r)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	r.HandleFunc("/secure", func(w http.ResponseWriter, r *http.Request) {}).Authorize("test")
+
+	rsp := httptest.NewRecorder()
+	r.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/secure", nil))
+
+	if rsp.Code != http.StatusUnauthorized {
+		t.Errorf("Middleware(authorization) failed: got status %v, expected %v", rsp.Code, http.StatusUnauthorized)
+	}
+	if result != "generated" {
+		t.Errorf("Middleware(authorization) failed: got request id %v, expected generated", result)
+	}
+}
@@ -0,0 +1,19 @@
+package requestid
+
+func WithHeaderName(name string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.HeaderName = name
+	}
+}
+
+func WithGenerator(generator Generator) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Generator = generator
+	}
+}
+
+func WithIgnoreRequestHeader(ignore bool) MiddlewareOption {
+	return func(m *Middleware) {
+		m.IgnoreRequest = ignore
+	}
+}
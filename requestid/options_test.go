@@ -0,0 +1,36 @@
+package requestid
+
+import (
+	"testing"
+)
+
+func Test_WithHeaderName(t *testing.T) {
+	expected := "test"
+	m := &Middleware{}
+	option := WithHeaderName(expected)
+	option(m)
+
+	if m.HeaderName != expected {
+		t.Errorf("WithHeaderName() failed: got %s, expected %s", m.HeaderName, expected)
+	}
+}
+
+func Test_WithGenerator(t *testing.T) {
+	m := &Middleware{}
+	option := WithGenerator(NewULID)
+	option(m)
+
+	if m.Generator == nil {
+		t.Error("WithGenerator() failed: generator not set")
+	}
+}
+
+func Test_WithIgnoreRequestHeader(t *testing.T) {
+	m := &Middleware{}
+	option := WithIgnoreRequestHeader(true)
+	option(m)
+
+	if !m.IgnoreRequest {
+		t.Error("WithIgnoreRequestHeader() failed: got false, expected true")
+	}
+}
@@ -0,0 +1,60 @@
+package requestid
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_NewUUID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	first := NewUUID()
+	if !pattern.MatchString(first) {
+		t.Errorf("NewUUID() failed: invalid format %v", first)
+	}
+	if second := NewUUID(); second == first {
+		t.Error("NewUUID() failed: generated duplicate value")
+	}
+}
+
+func Test_NewULID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
+	result := NewULID()
+	if !pattern.MatchString(result) {
+		t.Errorf("NewULID() failed: invalid format %v", result)
+	}
+}
+
+func Test_newULID_Timestamp(t *testing.T) {
+	earlier := newULID(time.UnixMilli(1000))
+	later := newULID(time.UnixMilli(2000))
+	if strings.Compare(earlier[:10], later[:10]) >= 0 {
+		t.Errorf("newULID() failed: timestamp not sortable: %v >= %v", earlier, later)
+	}
+	if earlier[:10] != "00000000Z8" {
+		t.Errorf("newULID() failed: got timestamp %v, expected 00000000Z8", earlier[:10])
+	}
+}
+
+func Test_isValid(t *testing.T) {
+	type testCase struct {
+		value    string
+		expected bool
+	}
+	tests := []testCase{
+		{"", false},
+		{"abc-123", true},
+		{"with space", false},
+		{"line\nbreak", false},
+		{strings.Repeat("a", MaxLength), true},
+		{strings.Repeat("a", MaxLength+1), false},
+	}
+
+	for _, tc := range tests {
+		result := isValid(tc.value)
+		if result != tc.expected {
+			t.Errorf("isValid(%q) failed: got %v, expected %v", tc.value, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package router
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+type ResponseWriter interface {
+	http.ResponseWriter
+	Status() int
+	BytesWritten() int64
+	HeaderWritten() bool
+	Hijacked() bool
+	Unwrap() http.ResponseWriter
+}
+
+type responseWriter struct {
+	writer        http.ResponseWriter
+	status        int
+	bytes         int64
+	headerWritten bool
+	hijacked      bool
+}
+
+type responseFlusher struct{ w *responseWriter }
+type responseHijacker struct{ w *responseWriter }
+type responseReaderFrom struct{ w *responseWriter }
+type responsePusher struct{ w *responseWriter }
+
+const (
+	capabilityFlusher = 1 << iota
+	capabilityHijacker
+	capabilityReaderFrom
+	capabilityPusher
+)
+
+func NewResponseWriter(w http.ResponseWriter) ResponseWriter {
+	if rw, ok := w.(ResponseWriter); ok {
+		return rw
+	}
+
+	rw := &responseWriter{writer: w}
+	f := responseFlusher{rw}
+	h := responseHijacker{rw}
+	rf := responseReaderFrom{rw}
+	p := responsePusher{rw}
+
+	capabilities := 0
+	if _, ok := w.(http.Flusher); ok {
+		capabilities |= capabilityFlusher
+	}
+	if _, ok := w.(http.Hijacker); ok {
+		capabilities |= capabilityHijacker
+	}
+	if _, ok := w.(io.ReaderFrom); ok {
+		capabilities |= capabilityReaderFrom
+	}
+	if _, ok := w.(http.Pusher); ok {
+		capabilities |= capabilityPusher
+	}
+
+	switch capabilities {
+	case capabilityFlusher:
+		return struct {
+			*responseWriter
+			responseFlusher
+		}{rw, f}
+	case capabilityHijacker:
+		return struct {
+			*responseWriter
+			responseHijacker
+		}{rw, h}
+	case capabilityFlusher | capabilityHijacker:
+		return struct {
+			*responseWriter
+			responseFlusher
+			responseHijacker
+		}{rw, f, h}
+	case capabilityReaderFrom:
+		return struct {
+			*responseWriter
+			responseReaderFrom
+		}{rw, rf}
+	case capabilityFlusher | capabilityReaderFrom:
+		return struct {
+			*responseWriter
+			responseFlusher
+			responseReaderFrom
+		}{rw, f, rf}
+	case capabilityHijacker | capabilityReaderFrom:
+		return struct {
+			*responseWriter
+			responseHijacker
+			responseReaderFrom
+		}{rw, h, rf}
+	case capabilityFlusher | capabilityHijacker | capabilityReaderFrom:
+		return struct {
+			*responseWriter
+			responseFlusher
+			responseHijacker
+			responseReaderFrom
+		}{rw, f, h, rf}
+	case capabilityPusher:
+		return struct {
+			*responseWriter
+			responsePusher
+		}{rw, p}
+	case capabilityFlusher | capabilityPusher:
+		return struct {
+			*responseWriter
+			responseFlusher
+			responsePusher
+		}{rw, f, p}
+	case capabilityHijacker | capabilityPusher:
+		return struct {
+			*responseWriter
+			responseHijacker
+			responsePusher
+		}{rw, h, p}
+	case capabilityFlusher | capabilityHijacker | capabilityPusher:
+		return struct {
+			*responseWriter
+			responseFlusher
+			responseHijacker
+			responsePusher
+		}{rw, f, h, p}
+	case capabilityReaderFrom | capabilityPusher:
+		return struct {
+			*responseWriter
+			responseReaderFrom
+			responsePusher
+		}{rw, rf, p}
+	case capabilityFlusher | capabilityReaderFrom | capabilityPusher:
+		return struct {
+			*responseWriter
+			responseFlusher
+			responseReaderFrom
+			responsePusher
+		}{rw, f, rf, p}
+	case capabilityHijacker | capabilityReaderFrom | capabilityPusher:
+		return struct {
+			*responseWriter
+			responseHijacker
+			responseReaderFrom
+			responsePusher
+		}{rw, h, rf, p}
+	case capabilityFlusher | capabilityHijacker | capabilityReaderFrom | capabilityPusher:
+		return struct {
+			*responseWriter
+			responseFlusher
+			responseHijacker
+			responseReaderFrom
+			responsePusher
+		}{rw, f, h, rf, p}
+	}
+	return rw
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.writer.Header()
+}
+
+func (w *responseWriter) WriteHeader(statusCode int) {
+	if w.headerWritten || w.hijacked {
+		return
+	}
+	if statusCode >= 100 && statusCode < 200 && statusCode != http.StatusSwitchingProtocols {
+		w.writer.WriteHeader(statusCode)
+		return
+	}
+	w.status = statusCode
+	w.headerWritten = true
+	w.writer.WriteHeader(statusCode)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.headerWritten {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.writer.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Status() int {
+	return w.status
+}
+
+func (w *responseWriter) BytesWritten() int64 {
+	return w.bytes
+}
+
+func (w *responseWriter) HeaderWritten() bool {
+	return w.headerWritten
+}
+
+func (w *responseWriter) Hijacked() bool {
+	return w.hijacked
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
+func (f responseFlusher) Flush() {
+	if !f.w.headerWritten {
+		f.w.WriteHeader(http.StatusOK)
+	}
+	f.w.writer.(http.Flusher).Flush()
+}
+
+func (h responseHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := h.w.writer.(http.Hijacker).Hijack()
+	if err == nil {
+		h.w.hijacked = true
+	}
+	return conn, rw, err
+}
+
+func (rf responseReaderFrom) ReadFrom(r io.Reader) (int64, error) {
+	if !rf.w.headerWritten {
+		rf.w.WriteHeader(http.StatusOK)
+	}
+	n, err := rf.w.writer.(io.ReaderFrom).ReadFrom(r)
+	rf.w.bytes += n
+	return n, err
+}
+
+func (p responsePusher) Push(target string, opts *http.PushOptions) error {
+	return p.w.writer.(http.Pusher).Push(target, opts)
+}
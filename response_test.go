@@ -0,0 +1,162 @@
+package router
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type flusherMock struct {
+	http.ResponseWriter
+	flushed int
+}
+
+func (m *flusherMock) Flush() {
+	m.flushed++
+}
+
+type hijackerMock struct {
+	http.ResponseWriter
+}
+
+func (m *hijackerMock) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+type fullWriterMock struct {
+	*httptest.ResponseRecorder
+}
+
+func (m *fullWriterMock) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+func (m *fullWriterMock) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(m.ResponseRecorder, r)
+}
+
+func (m *fullWriterMock) Push(target string, opts *http.PushOptions) error {
+	return nil
+}
+
+func Test_NewResponseWriter_Interfaces(t *testing.T) {
+	type testCase struct {
+		name       string
+		writer     http.ResponseWriter
+		flusher    bool
+		hijacker   bool
+		readerFrom bool
+		pusher     bool
+	}
+	tests := []testCase{
+		{"plain", &responseWriterMock{}, false, false, false, false},
+		{"flusher", &flusherMock{ResponseWriter: &responseWriterMock{}}, true, false, false, false},
+		{"hijacker", &hijackerMock{ResponseWriter: &responseWriterMock{}}, false, true, false, false},
+		{"recorder", httptest.NewRecorder(), true, false, false, false},
+		{"full", &fullWriterMock{httptest.NewRecorder()}, true, true, true, true},
+	}
+
+	for _, tc := range tests {
+		rw := NewResponseWriter(tc.writer)
+		if _, ok := rw.(http.Flusher); ok != tc.flusher {
+			t.Errorf("NewResponseWriter(%s) failed: http.Flusher got %v, expected %v", tc.name, ok, tc.flusher)
+		}
+		if _, ok := rw.(http.Hijacker); ok != tc.hijacker {
+			t.Errorf("NewResponseWriter(%s) failed: http.Hijacker got %v, expected %v", tc.name, ok, tc.hijacker)
+		}
+		if _, ok := rw.(io.ReaderFrom); ok != tc.readerFrom {
+			t.Errorf("NewResponseWriter(%s) failed: io.ReaderFrom got %v, expected %v", tc.name, ok, tc.readerFrom)
+		}
+		if _, ok := rw.(http.Pusher); ok != tc.pusher {
+			t.Errorf("NewResponseWriter(%s) failed: http.Pusher got %v, expected %v", tc.name, ok, tc.pusher)
+		}
+		if rw.Unwrap() != tc.writer {
+			t.Errorf("NewResponseWriter(%s) failed: Unwrap() does not return the wrapped writer", tc.name)
+		}
+	}
+}
+
+func Test_NewResponseWriter_Reuse(t *testing.T) {
+	rw := NewResponseWriter(httptest.NewRecorder())
+	if result := NewResponseWriter(rw); result != rw {
+		t.Error("NewResponseWriter(ResponseWriter) failed: writer wrapped twice")
+	}
+}
+
+func Test_ResponseWriter_Write(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rw := NewResponseWriter(rec)
+
+	if rw.HeaderWritten() {
+		t.Error("ResponseWriter.HeaderWritten() failed: got true before write")
+	}
+	rw.Write([]byte("hello"))
+	rw.WriteHeader(http.StatusTeapot)
+
+	if rw.Status() != http.StatusOK {
+		t.Errorf("ResponseWriter.Status() failed: got %v, expected %v", rw.Status(), http.StatusOK)
+	}
+	if rw.BytesWritten() != 5 {
+		t.Errorf("ResponseWriter.BytesWritten() failed: got %v, expected 5", rw.BytesWritten())
+	}
+	if !rw.HeaderWritten() {
+		t.Error("ResponseWriter.HeaderWritten() failed: got false after write")
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("ResponseWriter.WriteHeader() failed: superfluous status written: got %v", rec.Code)
+	}
+}
+
+func Test_ResponseWriter_WriteHeader_Informational(t *testing.T) {
+	rw := NewResponseWriter(httptest.NewRecorder())
+	rw.WriteHeader(http.StatusEarlyHints)
+	if rw.HeaderWritten() {
+		t.Error("ResponseWriter.WriteHeader(103) failed: informational status marked as written")
+	}
+	rw.WriteHeader(http.StatusAccepted)
+	if rw.Status() != http.StatusAccepted {
+		t.Errorf("ResponseWriter.WriteHeader() failed: got %v, expected %v", rw.Status(), http.StatusAccepted)
+	}
+}
+
+func Test_ResponseWriter_Flush(t *testing.T) {
+	mock := &flusherMock{ResponseWriter: httptest.NewRecorder()}
+	rw := NewResponseWriter(mock)
+	rw.(http.Flusher).Flush()
+
+	if mock.flushed != 1 {
+		t.Errorf("ResponseWriter.Flush() failed: got %v flushes, expected 1", mock.flushed)
+	}
+	if rw.Status() != http.StatusOK {
+		t.Errorf("ResponseWriter.Flush() failed: got status %v, expected %v", rw.Status(), http.StatusOK)
+	}
+}
+
+func Test_ResponseWriter_Hijack(t *testing.T) {
+	rw := NewResponseWriter(&hijackerMock{ResponseWriter: &responseWriterMock{}})
+	rw.(http.Hijacker).Hijack()
+
+	if !rw.Hijacked() {
+		t.Error("ResponseWriter.Hijack() failed: hijacked state not tracked")
+	}
+}
+
+func Test_ResponseWriter_ReadFrom(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rw := NewResponseWriter(&fullWriterMock{rec})
+	n, err := rw.(io.ReaderFrom).ReadFrom(strings.NewReader("hello"))
+
+	if err != nil || n != 5 {
+		t.Errorf("ResponseWriter.ReadFrom() failed: got %v, %v, expected 5, <nil>", n, err)
+	}
+	if rw.BytesWritten() != 5 {
+		t.Errorf("ResponseWriter.ReadFrom() failed: got %v bytes written, expected 5", rw.BytesWritten())
+	}
+	if rec.Body.String() != "hello" {
+		t.Errorf("ResponseWriter.ReadFrom() failed: got body %v, expected hello", rec.Body.String())
+	}
+}
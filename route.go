@@ -2,15 +2,23 @@ package router
 
 import (
 	"net/http"
+	"strings"
 )
 
 type RouteOption func(*Route)
+type RouteMatcher func(*http.Request) bool
+type MetadataKey string
 
 type Route struct {
 	node           *Node
 	methods        []string
 	handler        http.Handler
 	authPolicyName string
+	host           string
+	trailingSlash  bool
+	subtree        bool
+	matchers       []RouteMatcher
+	metadata       map[MetadataKey]any
 }
 
 func (r *Route) SubRouter() *Router {
@@ -42,6 +50,20 @@ func (r *Route) AllowedMethods(method ...string) *Route {
 	return r
 }
 
+func (r *Route) GetMethods() []string {
+	methods := make([]string, len(r.methods))
+	copy(methods, r.methods)
+	return methods
+}
+
+func (r *Route) GetHandler() http.Handler {
+	return r.handler
+}
+
+func (r *Route) HasHandler() bool {
+	return r.handler != nil
+}
+
 func (r *Route) IsMethodAllowed(method string) bool {
 	if len(r.methods) == 0 {
 		return true
@@ -54,6 +76,46 @@ func (r *Route) IsMethodAllowed(method string) bool {
 	return false
 }
 
+func (r *Route) Match(matcher RouteMatcher) *Route {
+	r.matchers = append(r.matchers, matcher)
+	return r
+}
+
+func (r *Route) Host(host string) *Route {
+	r.host = strings.ToLower(host)
+	return r
+}
+
+func (r *Route) GetHost() string {
+	return r.host
+}
+
+func (r *Route) priority() int {
+	priority := 0
+	if r.host != "" {
+		priority += 2
+	}
+	if r.trailingSlash {
+		priority++
+	}
+	return priority
+}
+
+func (r *Route) IsMatch(req *http.Request) bool {
+	if r.host != "" && r.host != requestHost(req) {
+		return false
+	}
+	if r.trailingSlash && !strings.HasSuffix(req.URL.Path, "/") {
+		return false
+	}
+	for _, matcher := range r.matchers {
+		if !matcher(req) {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *Route) Authorize(policyName string) {
 	r.authPolicyName = policyName
 }
@@ -66,6 +128,33 @@ func (r *Route) GetAuthorizationPolicy() string {
 	return r.authPolicyName
 }
 
+func (r *Route) SetMetadata(key MetadataKey, value any) *Route {
+	if r.metadata == nil {
+		r.metadata = make(map[MetadataKey]any)
+	}
+	r.metadata[key] = value
+	return r
+}
+
+func (r *Route) GetMetadata(key MetadataKey) (any, bool) {
+	if r.metadata == nil {
+		return nil, false
+	}
+	value, ok := r.metadata[key]
+	return value, ok
+}
+
+func (r *Route) GetNode() *Node {
+	return r.node
+}
+
+func (r *Route) GetTemplate() string {
+	if r.node == nil {
+		return ""
+	}
+	return r.node.GetTemplate()
+}
+
 func (r *Route) Handle(handler http.Handler) *Route {
 	r.handler = handler
 	return r
@@ -74,3 +163,7 @@ func (r *Route) Handle(handler http.Handler) *Route {
 func (r *Route) HandleFunc(handle http.HandlerFunc) *Route {
 	return r.Handle(http.HandlerFunc(handle))
 }
+
+func (r *Route) HandleErrorFunc(handle HandlerErrorFunc) *Route {
+	return r.Handle(handle)
+}
@@ -83,3 +83,88 @@ func Test_Route_IsMethodAllowed(t *testing.T) {
 		}
 	}
 }
+
+func Test_Route_GetTemplate(t *testing.T) {
+	router := NewRouter()
+	route := router.HandleFunc("/api/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	sub := router.PathPrefix("/admin").SubRouter()
+	subRoute := sub.HandleFunc("/users/{name}", func(w http.ResponseWriter, r *http.Request) {})
+
+	if result := route.GetTemplate(); result != "/api/{id}" {
+		t.Errorf("Route.GetTemplate() failed: got %v, expected /api/{id}", result)
+	}
+	if result := subRoute.GetTemplate(); result != "/admin/users/{name}" {
+		t.Errorf("Route.GetTemplate(subrouter) failed: got %v, expected /admin/users/{name}", result)
+	}
+	if result := (&Route{}).GetTemplate(); result != "" {
+		t.Errorf("Route.GetTemplate(nil node) failed: got %v, expected <empty>", result)
+	}
+}
+
+func Test_Route_Metadata(t *testing.T) {
+	route := &Route{}
+	if _, ok := route.GetMetadata("key"); ok {
+		t.Error("Route.GetMetadata(missing) failed: got true, expected false")
+	}
+
+	result := route.SetMetadata("key", 10)
+	if result != route {
+		t.Error("Route.SetMetadata() failed: result not equals instance")
+	}
+	value, ok := route.GetMetadata("key")
+	if !ok || value != 10 {
+		t.Errorf("Route.GetMetadata() failed: got %v, expected 10", value)
+	}
+}
+
+func Test_Route_GetMethods(t *testing.T) {
+	route := &Route{}
+	route.AllowedMethods(http.MethodGet, http.MethodPost)
+
+	methods := route.GetMethods()
+	if len(methods) != 2 || methods[0] != http.MethodGet || methods[1] != http.MethodPost {
+		t.Errorf("Route.GetMethods() failed: got %v, expected [GET POST]", methods)
+	}
+	methods[0] = http.MethodDelete
+	if route.IsMethodAllowed(http.MethodDelete) {
+		t.Error("Route.GetMethods() failed: result not a copy")
+	}
+}
+
+func Test_Route_HasHandler(t *testing.T) {
+	route := &Route{}
+	if route.HasHandler() {
+		t.Error("Route.HasHandler() failed: got true, expected false")
+	}
+	route.HandleFunc(func(w http.ResponseWriter, r *http.Request) {})
+	if !route.HasHandler() {
+		t.Error("Route.HasHandler() failed: got false, expected true")
+	}
+}
+
+func Test_Route_IsMatch(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/api", nil)
+	req.Header.Set("X-Version", "2")
+
+	route := &Route{}
+	if !route.IsMatch(req) {
+		t.Error("Route.IsMatch(no matchers) failed: got false, expected true")
+	}
+
+	result := route.Match(func(r *http.Request) bool {
+		return r.Header.Get("X-Version") == "2"
+	})
+	if result != route {
+		t.Error("Route.Match() failed: result not equals instance")
+	}
+	if !route.IsMatch(req) {
+		t.Error("Route.IsMatch(matching) failed: got false, expected true")
+	}
+
+	route.Match(func(r *http.Request) bool {
+		return false
+	})
+	if route.IsMatch(req) {
+		t.Error("Route.IsMatch(mismatch) failed: got true, expected false")
+	}
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"context"
 	"net/http"
+	"slices"
 	"strings"
 )
 
@@ -11,14 +12,18 @@ type RouteQuery map[string][]string
 type RouteParams map[string]string
 
 const (
-	routeKey  ContextKey = "router::route"
-	queryKey  ContextKey = "router::query"
-	paramsKey ContextKey = "router::params"
+	routeKey     ContextKey = "router::route"
+	queryKey     ContextKey = "router::query"
+	paramsKey    ContextKey = "router::params"
+	requestIdKey ContextKey = "router::requestid"
 )
 
 type Router struct {
-	tree        *Node
-	middlewares []Middleware
+	tree          *Node
+	middlewares   []Middleware
+	metadata      map[MetadataKey]any
+	errorRenderer ErrorRenderer
+	errorHandler  ErrorHandler
 }
 
 func NewRouter() *Router {
@@ -71,14 +76,36 @@ func Param(r *http.Request, key string) string {
 	return Params(r)[normalizedKey]
 }
 
+func RequestId(r *http.Request) string {
+	value := r.Context().Value(requestIdKey)
+	if value == nil {
+		return ""
+	}
+	return value.(string)
+}
+
+func SetRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey, requestId)
+}
+
 func (r *Router) PathPrefix(pattern string, opts ...RouteOption) *Route {
-	node := r.tree.BuildTree(pattern)
+	return r.addRoute(pattern, false, opts...)
+}
+
+func (r *Router) addRoute(pattern string, subtree bool, opts ...RouteOption) *Route {
+	p, ok := parsePattern(pattern)
+	if !ok {
+		return nil
+	}
+	node := r.tree.BuildTree(p.treePath(subtree))
 	if node == nil {
 		return nil
 	}
 	route := &Route{
-		node: node,
+		node:    node,
+		subtree: subtree && p.isSubtree(),
 	}
+	p.apply(route)
 	for _, opt := range opts {
 		opt(route)
 	}
@@ -86,7 +113,7 @@ func (r *Router) PathPrefix(pattern string, opts ...RouteOption) *Route {
 	if node.Routes == nil {
 		node.Routes = make([]*Route, 0)
 	}
-	node.Routes = append(node.Routes, route)
+	node.Routes = insertRoute(node.Routes, route)
 	return route
 }
 
@@ -94,19 +121,51 @@ func (r *Router) HandleFunc(pattern string, handle http.HandlerFunc, opts ...Rou
 	return r.Handle(pattern, http.HandlerFunc(handle), opts...)
 }
 
+func (r *Router) HandleErrorFunc(pattern string, handle HandlerErrorFunc, opts ...RouteOption) *Route {
+	return r.Handle(pattern, handle, opts...)
+}
+
 func (r *Router) Handle(pattern string, handler http.Handler, opts ...RouteOption) *Route {
-	route := r.PathPrefix(pattern, opts...)
+	route := r.addRoute(pattern, true, opts...)
 	if route != nil {
 		route.Handle(handler)
 	}
 	return route
 }
 
+func (r *Router) Routes() []*Route {
+	routes := make([]*Route, 0)
+	r.tree.Walk(func(n *Node) {
+		for _, route := range n.Routes {
+			if route.handler != nil {
+				routes = append(routes, route)
+			}
+		}
+	})
+	return routes
+}
+
+func insertRoute(routes []*Route, route *Route) []*Route {
+	priority := route.priority()
+	for i, existing := range routes {
+		if existing.priority() < priority {
+			return slices.Insert(routes, i, route)
+		}
+	}
+	return append(routes, route)
+}
+
 func (r *Router) findRoute(pattern string, params RouteParams) []*Route {
 	node := r.tree.FindNode(pattern, params)
 	if node == nil {
 		return nil
 	}
+	if !node.hasHandler() {
+		// Paths ending at an intermediate node fall back to the closest wildcard route above it
+		if fallback := r.tree.matchParentWildcard(node, pattern, params); fallback != nil {
+			node = fallback
+		}
+	}
 	if len(node.Routes) == 0 {
 		return nil
 	}
@@ -118,11 +177,12 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	params := make(RouteParams)
 	routes := r.findRoute(path, params)
 	if len(routes) == 0 {
-		http.NotFound(w, req)
+		r.notFound(r.tree, w, req)
 		return
 	}
 
 	hasHandler := false
+	hasMismatch := false
 	for _, route := range routes {
 		if route.handler == nil {
 			continue
@@ -130,19 +190,101 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		hasHandler = true
 
 		if route.IsMethodAllowed(req.Method) {
+			if !route.IsMatch(req) {
+				hasMismatch = true
+				continue
+			}
 			r.serverRoute(route, params, w, req)
 			return
 		}
 	}
 
-	if hasHandler {
-		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
-	} else {
-		http.NotFound(w, req)
+	node := routes[0].node
+	if !hasHandler || hasMismatch {
+		r.notFound(node, w, req)
+		return
 	}
+
+	if req.Method == http.MethodOptions {
+		r.serveOptions(node, params, w, req)
+		return
+	}
+	w.Header().Set("Allow", strings.Join(node.GetAllowedMethods(), ", "))
+	if renderer := getErrorRenderer(node); renderer != nil {
+		renderer.RenderError(w, req, NewProblem(http.StatusMethodNotAllowed, ""))
+		return
+	}
+	http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
+}
+
+func (r *Router) SetErrorRenderer(renderer ErrorRenderer) *Router {
+	r.errorRenderer = renderer
+	return r
+}
+
+func (r *Router) UseProblemDetails() *Router {
+	return r.SetErrorRenderer(ErrorRendererFunc(ProblemErrorRenderer))
+}
+
+func (r *Router) GetErrorRenderer() ErrorRenderer {
+	return r.errorRenderer
+}
+
+func (r *Router) SetErrorHandler(handler ErrorHandler) *Router {
+	r.errorHandler = handler
+	return r
+}
+
+func (r *Router) GetErrorHandler() ErrorHandler {
+	return r.errorHandler
+}
+
+func (r *Router) SetMetadata(key MetadataKey, value any) *Router {
+	if r.metadata == nil {
+		r.metadata = make(map[MetadataKey]any)
+	}
+	r.metadata[key] = value
+	return r
+}
+
+func (r *Router) GetMetadata(key MetadataKey) (any, bool) {
+	if r.metadata == nil {
+		return nil, false
+	}
+	value, ok := r.metadata[key]
+	return value, ok
+}
+
+func (r *Router) serveOptions(node *Node, params RouteParams, w http.ResponseWriter, req *http.Request) {
+	var route *Route
+	for _, candidate := range node.Routes {
+		if candidate.handler != nil {
+			route = candidate
+			break
+		}
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Allow", strings.Join(node.GetAllowedMethods(), ", "))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.serveHandler(route, handler, params, w, req)
 }
 
 func (r *Router) serverRoute(route *Route, params RouteParams, w http.ResponseWriter, req *http.Request) {
+	if route.subtree && !strings.HasSuffix(req.URL.Path, "/") && params[route.node.Segment] == "" {
+		// Like http.ServeMux, the subtree root without its trailing slash redirects to it
+		u := *req.URL
+		u.Path += "/"
+		http.Redirect(w, req, u.String(), http.StatusMovedPermanently)
+		return
+	}
+	if route.subtree {
+		delete(params, route.node.Segment)
+	}
+	r.serveHandler(route, route.handler, params, w, req)
+}
+
+func (r *Router) serveHandler(route *Route, handler http.Handler, params RouteParams, w http.ResponseWriter, req *http.Request) {
 	urlValues := req.URL.Query()
 	query := make(RouteQuery)
 	for key, values := range urlValues {
@@ -154,13 +296,38 @@ func (r *Router) serverRoute(route *Route, params RouteParams, w http.ResponseWr
 	ctx = context.WithValue(ctx, queryKey, query)
 	ctx = context.WithValue(ctx, paramsKey, params)
 
-	middlewares := r.getMiddleware(route.node, nil)
+	var node *Node
+	if route != nil {
+		node = route.node
+	}
+	middlewares := r.getMiddleware(node, nil)
 
-	handler := route.handler
 	for i := len(middlewares) - 1; i >= 0; i-- {
 		handler = middlewares[i].Middleware(handler)
 	}
-	handler.ServeHTTP(w, req.WithContext(ctx))
+
+	req = req.WithContext(ctx)
+	setPathValues(req, node, params)
+	handler.ServeHTTP(w, req)
+}
+
+func setPathValues(req *http.Request, node *Node, params RouteParams) {
+	for ; node != nil; node = node.Parent {
+		if node.Type != NodeTypeParam && node.Type != NodeTypeWildcard {
+			continue
+		}
+		if value, ok := params[node.Segment]; ok && node.Name != "" {
+			req.SetPathValue(node.Name, value)
+		}
+	}
+}
+
+func (r *Router) notFound(node *Node, w http.ResponseWriter, req *http.Request) {
+	if renderer := getErrorRenderer(node); renderer != nil {
+		renderer.RenderError(w, req, NewProblem(http.StatusNotFound, ""))
+		return
+	}
+	http.NotFound(w, req)
 }
 
 func (r *Router) getMiddleware(node *Node, middlewares []Middleware) []Middleware {
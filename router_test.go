@@ -3,7 +3,9 @@ package router
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -77,6 +79,18 @@ func Test_CurrentRoute(t *testing.T) {
 
 }
 
+func Test_RequestId(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	if result := RequestId(req); result != "" {
+		t.Errorf("RequestId(default) failed: got %v, expected <empty>", result)
+	}
+
+	req = req.WithContext(SetRequestId(req.Context(), "abc"))
+	if result := RequestId(req); result != "abc" {
+		t.Errorf("RequestId() failed: got %v, expected abc", result)
+	}
+}
+
 func Test_QueryValues(t *testing.T) {
 	type testCase struct {
 		lookup   string
@@ -427,3 +441,243 @@ func Test_Router_ServeHttp_MultiMethod_MultiHandler(t *testing.T) {
 		t.Errorf("Router.ServerHTTP(%s) failed: handler called %v times, expected 1", http.MethodPut, putHandlerCalled)
 	}
 }
+
+func Test_Router_ServeHttp_Options(t *testing.T) {
+	middlewareCalled := 0
+	handlerCalled := 0
+	handle := func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled++
+	}
+
+	router := NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			middlewareCalled++
+			if CurrentRoute(r) == nil {
+				t.Error("Router.ServeHTTP(OPTIONS) failed: current route not set")
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	router.HandleFunc("/api", handle).AllowedMethod(http.MethodGet)
+	router.HandleFunc("/api", handle).AllowedMethod(http.MethodPut)
+
+	req := httptest.NewRequest(http.MethodOptions, "/api", nil)
+	rsp := httptest.NewRecorder()
+	router.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusNoContent {
+		t.Errorf("Router.ServeHTTP(OPTIONS) failed: got status %v, expected %v", rsp.Code, http.StatusNoContent)
+	}
+	if allow := rsp.Header().Get("Allow"); allow != "GET, PUT, OPTIONS" {
+		t.Errorf("Router.ServeHTTP(OPTIONS) failed: got Allow %v, expected GET, PUT, OPTIONS", allow)
+	}
+	if middlewareCalled != 1 {
+		t.Errorf("Router.ServeHTTP(OPTIONS) failed: middleware called %v times, expected 1", middlewareCalled)
+	}
+	if handlerCalled != 0 {
+		t.Errorf("Router.ServeHTTP(OPTIONS) failed: handler called %v times, expected 0", handlerCalled)
+	}
+}
+
+func Test_Router_ServeHttp_MethodNotAllowed_AllowHeader(t *testing.T) {
+	router := NewRouter()
+	router.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {}).AllowedMethods(http.MethodGet, http.MethodPost)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api", nil)
+	rsp := httptest.NewRecorder()
+	router.ServeHTTP(rsp, req)
+
+	if rsp.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Router.ServeHTTP(DELETE) failed: got status %v, expected %v", rsp.Code, http.StatusMethodNotAllowed)
+	}
+	if allow := rsp.Header().Get("Allow"); allow != "GET, POST, OPTIONS" {
+		t.Errorf("Router.ServeHTTP(DELETE) failed: got Allow %v, expected GET, POST, OPTIONS", allow)
+	}
+}
+
+func Test_Router_Metadata(t *testing.T) {
+	router := &Router{}
+	if _, ok := router.GetMetadata("key"); ok {
+		t.Error("Router.GetMetadata(missing) failed: got true, expected false")
+	}
+
+	result := router.SetMetadata("key", "value")
+	if result != router {
+		t.Error("Router.SetMetadata() failed: result not equals instance")
+	}
+	value, ok := router.GetMetadata("key")
+	if !ok || value != "value" {
+		t.Errorf("Router.GetMetadata() failed: got %v, expected value", value)
+	}
+}
+
+func Test_Router_Routes(t *testing.T) {
+	router := NewRouter()
+	router.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {})
+	router.HandleFunc("/api/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	sub := router.PathPrefix("/admin").SubRouter()
+	sub.HandleFunc("/settings", func(w http.ResponseWriter, r *http.Request) {})
+
+	templates := make([]string, 0)
+	for _, route := range router.Routes() {
+		templates = append(templates, route.GetTemplate())
+	}
+	expected := []string{"/api/users", "/api/users/{id}", "/admin/settings"}
+	if !slices.Equal(templates, expected) {
+		t.Errorf("Router.Routes() failed: got %v, expected %v", templates, expected)
+	}
+	if len(sub.Routes()) != 1 {
+		t.Errorf("Router.Routes(subrouter) failed: got %v routes, expected 1", len(sub.Routes()))
+	}
+}
+
+func Test_Router_ServeHttp_Matcher(t *testing.T) {
+	type testCase struct {
+		version  string
+		expected string
+		status   int
+	}
+	tests := []testCase{
+		{"1", "v1", http.StatusOK},
+		{"2", "v2", http.StatusOK},
+		{"3", "", http.StatusNotFound},
+	}
+
+	versionMatcher := func(version string) RouteMatcher {
+		return func(r *http.Request) bool {
+			return r.Header.Get("X-Version") == version
+		}
+	}
+	router := NewRouter()
+	router.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("v1"))
+	}, Matcher(versionMatcher("1")))
+	router.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("v2"))
+	}, Matcher(versionMatcher("2")))
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.Header.Set("X-Version", tc.version)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != tc.status {
+			t.Errorf("Router.ServeHTTP(%s) failed: got status %v, expected %v", tc.version, rec.Code, tc.status)
+		}
+		if tc.expected != "" && rec.Body.String() != tc.expected {
+			t.Errorf("Router.ServeHTTP(%s) failed: got %s, expected %s", tc.version, rec.Body.String(), tc.expected)
+		}
+	}
+}
+
+func Test_Router_ServeHttp_PathValue(t *testing.T) {
+	type testCase struct {
+		path     string
+		expected string
+	}
+	tests := []testCase{
+		{"/users/42/orders/7", "42:7:"},
+		{"/files/docs/readme.md", "::docs/readme.md"},
+		{"/accounts/5/items/9", "5:9:"},
+		{"/Alice", "Alice::"},
+		{"/USERS/Bob/Orders/Ab7", "Bob:Ab7:"},
+		{"/files/Docs/ReadMe.md", "::Docs/ReadMe.md"},
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue("user") + ":" + r.PathValue("order") + ":" + r.PathValue("path")))
+	}
+	router := NewRouter()
+	router.HandleFunc("/users/{user}/orders/{order}", handler)
+	router.HandleFunc("/files/{path...}", handler)
+	router.HandleFunc("/{user}", handler)
+	router.HandleFunc("/accounts/{userID}/items/{itemId}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue("userID") + ":" + r.PathValue("itemId") + ":" + r.PathValue("itemid")))
+	})
+
+	for _, tc := range tests {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Body.String() != tc.expected {
+			t.Errorf("Router.ServeHTTP(%s) failed: got %s, expected %s", tc.path, rec.Body.String(), tc.expected)
+		}
+	}
+}
+
+func Test_Router_ServeHttp_Precedence(t *testing.T) {
+	type testCase struct {
+		path     string
+		expected string
+	}
+	tests := []testCase{
+		{"/items/new", "new"},
+		{"/items/42", "id:42"},
+		{"/items/new/lines", "id:new:lines"},
+		{"/items/42/files/a/b", "files:a/b"},
+		{"/items/other/extra/path", "rest:other/extra/path"},
+	}
+
+	router := NewRouter()
+	router.HandleFunc("/items/{rest...}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("rest:" + r.PathValue("rest")))
+	})
+	router.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("id:" + r.PathValue("id")))
+	})
+	router.HandleFunc("/items/{id}/lines", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("id:" + r.PathValue("id") + ":lines"))
+	})
+	router.HandleFunc("/items/{id}/files/{file...}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("files:" + r.PathValue("file")))
+	})
+	router.HandleFunc("/items/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("new"))
+	})
+
+	for _, tc := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+		if rec.Body.String() != tc.expected {
+			t.Errorf("Router.ServeHTTP(%s) failed: got %s, expected %s", tc.path, rec.Body.String(), tc.expected)
+		}
+	}
+}
+
+func Test_Router_ServeHttp_ProblemDetails(t *testing.T) {
+	type testCase struct {
+		method string
+		path   string
+		status int
+	}
+	tests := []testCase{
+		{http.MethodGet, "/missing", http.StatusNotFound},
+		{http.MethodDelete, "/api", http.StatusMethodNotAllowed},
+	}
+
+	router := NewRouter()
+	result := router.UseProblemDetails()
+	if result != router {
+		t.Error("Router.UseProblemDetails() failed: result not equals instance")
+	}
+	if router.GetErrorRenderer() == nil {
+		t.Error("Router.UseProblemDetails() failed: error renderer not set")
+	}
+	router.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {}).AllowedMethod(http.MethodGet)
+
+	for _, tc := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+
+		if rec.Code != tc.status {
+			t.Errorf("Router.ServeHTTP(%s %s) failed: got status %v, expected %v", tc.method, tc.path, rec.Code, tc.status)
+		}
+		if rec.Header().Get("Content-Type") != ProblemContentType {
+			t.Errorf("Router.ServeHTTP(%s %s) failed: got content type %s, expected %s", tc.method, tc.path, rec.Header().Get("Content-Type"), ProblemContentType)
+		}
+	}
+}
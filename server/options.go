@@ -0,0 +1,82 @@
+package server
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"time"
+)
+
+func WithAddr(addr string) ServerOption {
+	return func(s *Server) {
+		s.Addr = addr
+	}
+}
+
+func WithTLS(addr string, certFile string, keyFile string) ServerOption {
+	return func(s *Server) {
+		s.TLSAddr = addr
+		s.CertFile = certFile
+		s.KeyFile = keyFile
+	}
+}
+
+func WithTLSConfig(config *tls.Config) ServerOption {
+	return func(s *Server) {
+		s.TLSConfig = config
+	}
+}
+
+func WithReadHeaderTimeout(d time.Duration) ServerOption {
+	return func(s *Server) {
+		s.ReadHeaderTimeout = d
+	}
+}
+
+func WithReadTimeout(d time.Duration) ServerOption {
+	return func(s *Server) {
+		s.ReadTimeout = d
+	}
+}
+
+func WithWriteTimeout(d time.Duration) ServerOption {
+	return func(s *Server) {
+		s.WriteTimeout = d
+	}
+}
+
+func WithIdleTimeout(d time.Duration) ServerOption {
+	return func(s *Server) {
+		s.IdleTimeout = d
+	}
+}
+
+func WithShutdownTimeout(d time.Duration) ServerOption {
+	return func(s *Server) {
+		s.ShutdownTimeout = d
+	}
+}
+
+func WithDrainDelay(d time.Duration) ServerOption {
+	return func(s *Server) {
+		s.DrainDelay = d
+	}
+}
+
+func WithSignals(signals ...os.Signal) ServerOption {
+	return func(s *Server) {
+		s.Signals = append([]os.Signal{}, signals...)
+	}
+}
+
+func WithLogger(logger *slog.Logger) ServerOption {
+	return func(s *Server) {
+		s.Logger = logger
+	}
+}
+
+func WithShutdownHook(hook ShutdownHook) ServerOption {
+	return func(s *Server) {
+		s.ShutdownHooks = append(s.ShutdownHooks, hook)
+	}
+}
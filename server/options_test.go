@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_WithAddr(t *testing.T) {
+	s := &Server{}
+	option := WithAddr(":9090")
+	option(s)
+
+	if s.Addr != ":9090" {
+		t.Errorf("WithAddr() failed: got %s, expected %s", s.Addr, ":9090")
+	}
+}
+
+func Test_WithTLS(t *testing.T) {
+	s := &Server{}
+	option := WithTLS(":8443", "cert.pem", "key.pem")
+	option(s)
+
+	if s.TLSAddr != ":8443" || s.CertFile != "cert.pem" || s.KeyFile != "key.pem" {
+		t.Errorf("WithTLS() failed: got %s %s %s, expected %s %s %s", s.TLSAddr, s.CertFile, s.KeyFile, ":8443", "cert.pem", "key.pem")
+	}
+}
+
+func Test_WithTLSConfig(t *testing.T) {
+	config := &tls.Config{}
+	s := &Server{}
+	option := WithTLSConfig(config)
+	option(s)
+
+	if s.TLSConfig != config {
+		t.Errorf("WithTLSConfig() failed: got %v, expected %v", s.TLSConfig, config)
+	}
+}
+
+func Test_WithTimeouts(t *testing.T) {
+	s := &Server{}
+	WithReadHeaderTimeout(time.Second)(s)
+	WithReadTimeout(2 * time.Second)(s)
+	WithWriteTimeout(3 * time.Second)(s)
+	WithIdleTimeout(4 * time.Second)(s)
+	WithShutdownTimeout(5 * time.Second)(s)
+	WithDrainDelay(6 * time.Second)(s)
+
+	if s.ReadHeaderTimeout != time.Second {
+		t.Errorf("WithReadHeaderTimeout() failed: got %v, expected %v", s.ReadHeaderTimeout, time.Second)
+	}
+	if s.ReadTimeout != 2*time.Second {
+		t.Errorf("WithReadTimeout() failed: got %v, expected %v", s.ReadTimeout, 2*time.Second)
+	}
+	if s.WriteTimeout != 3*time.Second {
+		t.Errorf("WithWriteTimeout() failed: got %v, expected %v", s.WriteTimeout, 3*time.Second)
+	}
+	if s.IdleTimeout != 4*time.Second {
+		t.Errorf("WithIdleTimeout() failed: got %v, expected %v", s.IdleTimeout, 4*time.Second)
+	}
+	if s.ShutdownTimeout != 5*time.Second {
+		t.Errorf("WithShutdownTimeout() failed: got %v, expected %v", s.ShutdownTimeout, 5*time.Second)
+	}
+	if s.DrainDelay != 6*time.Second {
+		t.Errorf("WithDrainDelay() failed: got %v, expected %v", s.DrainDelay, 6*time.Second)
+	}
+}
+
+func Test_WithSignals(t *testing.T) {
+	s := &Server{}
+	option := WithSignals(os.Interrupt)
+	option(s)
+
+	if len(s.Signals) != 1 || s.Signals[0] != os.Interrupt {
+		t.Errorf("WithSignals() failed: got %v, expected %v", s.Signals, []os.Signal{os.Interrupt})
+	}
+
+	option = WithSignals()
+	option(s)
+	s.EnsureDefaults()
+	if s.Signals == nil || len(s.Signals) != 0 {
+		t.Errorf("WithSignals() failed: got %v, expected no signals", s.Signals)
+	}
+}
+
+func Test_WithLogger(t *testing.T) {
+	logger := slog.Default()
+	s := &Server{}
+	option := WithLogger(logger)
+	option(s)
+
+	if s.Logger != logger {
+		t.Errorf("WithLogger() failed: got %v, expected %v", s.Logger, logger)
+	}
+}
+
+func Test_WithShutdownHook(t *testing.T) {
+	s := &Server{}
+	option := WithShutdownHook(func(ctx context.Context) error {
+		return nil
+	})
+	option(s)
+
+	if len(s.ShutdownHooks) != 1 {
+		t.Errorf("WithShutdownHook() failed: got %v hooks, expected %v", len(s.ShutdownHooks), 1)
+	}
+}
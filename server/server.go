@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	DefaultAddr              string        = ":8080"
+	DefaultReadHeaderTimeout time.Duration = 10 * time.Second
+	DefaultReadTimeout       time.Duration = 30 * time.Second
+	DefaultWriteTimeout      time.Duration = 60 * time.Second
+	DefaultIdleTimeout       time.Duration = 120 * time.Second
+	DefaultShutdownTimeout   time.Duration = 30 * time.Second
+)
+
+var (
+	ErrServerStarted = errors.New("server already started")
+	ErrNoListeners   = errors.New("no listeners configured")
+)
+
+type ShutdownHook func(ctx context.Context) error
+
+type ServerOption func(*Server)
+
+type Server struct {
+	Handler           http.Handler
+	Addr              string
+	TLSAddr           string
+	CertFile          string
+	KeyFile           string
+	TLSConfig         *tls.Config
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+	DrainDelay        time.Duration
+	Signals           []os.Signal
+	Logger            *slog.Logger
+	ShutdownHooks     []ShutdownHook
+
+	mu       sync.Mutex
+	servers  []*http.Server
+	cancel   context.CancelFunc
+	started  atomic.Bool
+	ready    atomic.Bool
+	inFlight atomic.Int64
+}
+
+type listener struct {
+	net.Listener
+	tls bool
+}
+
+func NewServer(handler http.Handler, opts ...ServerOption) *Server {
+	s := &Server{
+		Handler: handler,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.EnsureDefaults()
+
+	return s
+}
+
+func ListenAndServe(ctx context.Context, handler http.Handler, opts ...ServerOption) error {
+	return NewServer(handler, opts...).Run(ctx)
+}
+
+func (s *Server) EnsureDefaults() {
+	if s.Handler == nil {
+		s.Handler = http.NotFoundHandler()
+	}
+	if s.Addr == "" && s.TLSAddr == "" {
+		s.Addr = DefaultAddr
+	}
+	if s.ReadHeaderTimeout <= 0 {
+		s.ReadHeaderTimeout = DefaultReadHeaderTimeout
+	}
+	if s.ReadTimeout <= 0 {
+		s.ReadTimeout = DefaultReadTimeout
+	}
+	if s.WriteTimeout <= 0 {
+		s.WriteTimeout = DefaultWriteTimeout
+	}
+	if s.IdleTimeout <= 0 {
+		s.IdleTimeout = DefaultIdleTimeout
+	}
+	if s.ShutdownTimeout <= 0 {
+		s.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if s.Signals == nil {
+		s.Signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	if s.Logger == nil {
+		s.Logger = slog.Default()
+	}
+}
+
+func (s *Server) OnShutdown(hook ShutdownHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ShutdownHooks = append(s.ShutdownHooks, hook)
+}
+
+func (s *Server) IsReady() bool {
+	return s.ready.Load()
+}
+
+func (s *Server) InFlight() int64 {
+	return s.inFlight.Load()
+}
+
+func (s *Server) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		if !s.IsReady() {
+			router.Error(w, r, router.NewProblem(http.StatusServiceUnavailable, ""))
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	listeners := make([]listener, 0, 2)
+	if s.Addr != "" {
+		ln, err := net.Listen("tcp", s.Addr)
+		if err != nil {
+			return err
+		}
+		listeners = append(listeners, listener{Listener: ln})
+	}
+	if s.TLSAddr != "" {
+		ln, err := net.Listen("tcp", s.TLSAddr)
+		if err != nil {
+			closeListeners(listeners)
+			return err
+		}
+		listeners = append(listeners, listener{Listener: ln, tls: true})
+	}
+	return s.serve(ctx, listeners)
+}
+
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	return s.serve(ctx, []listener{{Listener: ln}})
+}
+
+func (s *Server) ServeTLS(ctx context.Context, ln net.Listener) error {
+	return s.serve(ctx, []listener{{Listener: ln, tls: true}})
+}
+
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+func (s *Server) serve(ctx context.Context, listeners []listener) error {
+	if len(listeners) == 0 {
+		return ErrNoListeners
+	}
+	if !s.started.CompareAndSwap(false, true) {
+		closeListeners(listeners)
+		return ErrServerStarted
+	}
+
+	if len(s.Signals) > 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, s.Signals...)
+		defer stop()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Request contexts outlive the serve context until shutdown starts, so long-lived streams stop before the drain
+	baseCtx, cancelBase := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelBase()
+	errs := make(chan error, len(listeners))
+	s.mu.Lock()
+	s.cancel = cancel
+	for _, ln := range listeners {
+		srv := s.newHttpServer(baseCtx)
+		srv.RegisterOnShutdown(cancelBase)
+		s.servers = append(s.servers, srv)
+		go func() {
+			var err error
+			if ln.tls {
+				err = srv.ServeTLS(ln, s.CertFile, s.KeyFile)
+			} else {
+				err = srv.Serve(ln)
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- err
+			}
+		}()
+		s.Logger.Info("Server listening", slog.String("addr", ln.Addr().String()), slog.Bool("tls", ln.tls))
+	}
+	s.mu.Unlock()
+	s.ready.Store(true)
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+	case serveErr = <-errs:
+		s.Logger.Error("Server failed", slog.Any("error", serveErr))
+	}
+	return errors.Join(serveErr, s.shutdown())
+}
+
+func (s *Server) shutdown() error {
+	s.ready.Store(false)
+	s.Logger.Info("Server shutting down", slog.Int64("in_flight", s.InFlight()))
+	if s.DrainDelay > 0 {
+		time.Sleep(s.DrainDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+
+	s.mu.Lock()
+	servers := s.servers
+	hooks := s.ShutdownHooks
+	s.mu.Unlock()
+
+	errs := make([]error, 0)
+	for _, srv := range servers {
+		if err := srv.Shutdown(ctx); err != nil {
+			s.Logger.Warn("Server did not drain in time", slog.Int64("in_flight", s.InFlight()), slog.Any("error", err))
+			srv.Close()
+			errs = append(errs, err)
+		}
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.Logger.Info("Server stopped")
+	return errors.Join(errs...)
+}
+
+func (s *Server) newHttpServer(ctx context.Context) *http.Server {
+	return &http.Server{
+		Handler:           s.track(s.Handler),
+		TLSConfig:         s.TLSConfig,
+		ReadHeaderTimeout: s.ReadHeaderTimeout,
+		ReadTimeout:       s.ReadTimeout,
+		WriteTimeout:      s.WriteTimeout,
+		IdleTimeout:       s.IdleTimeout,
+		ErrorLog:          slog.NewLogLogger(s.Logger.Handler(), slog.LevelWarn),
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+}
+
+func (s *Server) track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.inFlight.Add(1)
+		defer s.inFlight.Add(-1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func closeListeners(listeners []listener) {
+	for _, ln := range listeners {
+		ln.Close()
+	}
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func newTestServer(t *testing.T, handler http.Handler, opts ...ServerOption) (*Server, net.Listener) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() failed: %v", err)
+	}
+	opts = append([]ServerOption{WithSignals(), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))}, opts...)
+	return NewServer(handler, opts...), ln
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("waitFor() failed: condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func Test_NewServer(t *testing.T) {
+	s := NewServer(nil)
+
+	if s.Handler == nil {
+		t.Error("NewServer() failed: handler not initialized")
+	}
+	if s.Addr != DefaultAddr {
+		t.Errorf("NewServer() failed: got addr %s, expected %s", s.Addr, DefaultAddr)
+	}
+	if s.ReadHeaderTimeout != DefaultReadHeaderTimeout {
+		t.Errorf("NewServer() failed: got read header timeout %v, expected %v", s.ReadHeaderTimeout, DefaultReadHeaderTimeout)
+	}
+	if s.ShutdownTimeout != DefaultShutdownTimeout {
+		t.Errorf("NewServer() failed: got shutdown timeout %v, expected %v", s.ShutdownTimeout, DefaultShutdownTimeout)
+	}
+	if len(s.Signals) != 2 {
+		t.Errorf("NewServer() failed: got %v signals, expected %v", len(s.Signals), 2)
+	}
+	if s.Logger == nil {
+		t.Error("NewServer() failed: logger not initialized")
+	}
+}
+
+func Test_NewServer_TLSOnly(t *testing.T) {
+	s := NewServer(nil, WithTLS(":8443", "cert.pem", "key.pem"))
+
+	if s.Addr != "" {
+		t.Errorf("NewServer() failed: got addr %s, expected no http listener", s.Addr)
+	}
+}
+
+func Test_Server_Serve(t *testing.T) {
+	r := router.NewRouter()
+	r.HandleFunc("GET /hello", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	})
+	s, ln := newTestServer(t, r)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(context.Background(), ln)
+	}()
+	waitFor(t, s.IsReady)
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/hello")
+	if err != nil {
+		t.Fatalf("http.Get() failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "hello" {
+		t.Errorf("Server.Serve() failed: got %s, expected %s", body, "hello")
+	}
+
+	s.Stop()
+	if err := <-done; err != nil {
+		t.Errorf("Server.Serve() failed: got %v, expected nil", err)
+	}
+	if s.IsReady() {
+		t.Error("Server.Serve() failed: still ready after shutdown")
+	}
+}
+
+func Test_Server_Serve_Started(t *testing.T) {
+	s, ln := newTestServer(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(ctx, ln)
+	}()
+	waitFor(t, s.IsReady)
+
+	second, _ := net.Listen("tcp", "127.0.0.1:0")
+	if err := s.Serve(ctx, second); !errors.Is(err, ErrServerStarted) {
+		t.Errorf("Server.Serve() failed: got %v, expected %v", err, ErrServerStarted)
+	}
+
+	cancel()
+	<-done
+}
+
+func Test_Server_Shutdown_Drain(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	})
+
+	var mu sync.Mutex
+	calls := make([]string, 0)
+	hook := func(name string) ShutdownHook {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, name)
+			return nil
+		}
+	}
+	s, ln := newTestServer(t, handler, WithShutdownHook(hook("first")))
+	s.OnShutdown(hook("second"))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(context.Background(), ln)
+	}()
+	waitFor(t, s.IsReady)
+
+	response := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/")
+		if err != nil {
+			response <- err.Error()
+			return
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		response <- string(body)
+	}()
+	<-started
+
+	if s.InFlight() != 1 {
+		t.Errorf("Server.InFlight() failed: got %v, expected %v", s.InFlight(), 1)
+	}
+	s.Stop()
+	waitFor(t, func() bool { return !s.IsReady() })
+	close(release)
+
+	if body := <-response; body != "done" {
+		t.Errorf("Server.Stop() failed: got %s, expected in-flight request to complete", body)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Server.Stop() failed: got %v, expected nil", err)
+	}
+	if s.InFlight() != 0 {
+		t.Errorf("Server.InFlight() failed: got %v, expected %v", s.InFlight(), 0)
+	}
+	if len(calls) != 2 || calls[0] != "second" || calls[1] != "first" {
+		t.Errorf("Server.Stop() failed: got hooks %v, expected [second first]", calls)
+	}
+}
+
+func Test_Server_Shutdown_Stream(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		http.NewResponseController(w).Flush()
+		close(started)
+		<-r.Context().Done()
+	})
+	s, ln := newTestServer(t, handler, WithShutdownTimeout(5*time.Second))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(context.Background(), ln)
+	}()
+	waitFor(t, s.IsReady)
+
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/")
+		if err == nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	start := time.Now()
+	s.Stop()
+	if err := <-done; err != nil {
+		t.Errorf("Server.Stop() failed: got %v, expected nil", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Server.Stop() failed: took %v, expected the stream to end when shutdown starts", elapsed)
+	}
+}
+
+func Test_Server_Shutdown_Timeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	hookErr := errors.New("hook failed")
+	s, ln := newTestServer(t, handler,
+		WithShutdownTimeout(50*time.Millisecond),
+		WithShutdownHook(func(ctx context.Context) error {
+			return hookErr
+		}),
+	)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Serve(context.Background(), ln)
+	}()
+	waitFor(t, s.IsReady)
+
+	go http.Get("http://" + ln.Addr().String() + "/")
+	<-started
+	s.Stop()
+
+	err := <-done
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Server.Stop() failed: got %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if !errors.Is(err, hookErr) {
+		t.Errorf("Server.Stop() failed: got %v, expected %v", err, hookErr)
+	}
+}
+
+func Test_Server_ReadinessHandler(t *testing.T) {
+	s := NewServer(nil)
+
+	rec := httptest.NewRecorder()
+	s.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Server.ReadinessHandler() failed: got %v, expected %v", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	s.ready.Store(true)
+	rec = httptest.NewRecorder()
+	s.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Server.ReadinessHandler() failed: got %v, expected %v", rec.Code, http.StatusOK)
+	}
+}
+
+func Test_Server_Serve_NoListeners(t *testing.T) {
+	s := NewServer(nil)
+	if err := s.serve(context.Background(), nil); !errors.Is(err, ErrNoListeners) {
+		t.Errorf("Server.serve() failed: got %v, expected %v", err, ErrNoListeners)
+	}
+}
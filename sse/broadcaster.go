@@ -0,0 +1,139 @@
+package sse
+
+import (
+	"net/http"
+	"sync"
+)
+
+const (
+	DefaultBufferSize int = 16
+)
+
+type BroadcasterOption func(*Broadcaster)
+
+type Broadcaster struct {
+	BufferSize int
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+type Subscription struct {
+	broadcaster *Broadcaster
+	events      chan Event
+	once        sync.Once
+}
+
+func NewBroadcaster(opts ...BroadcasterOption) *Broadcaster {
+	b := &Broadcaster{
+		subscribers: make(map[*Subscription]struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.EnsureDefaults()
+
+	return b
+}
+
+func (b *Broadcaster) EnsureDefaults() {
+	if b.BufferSize <= 0 {
+		b.BufferSize = DefaultBufferSize
+	}
+	if b.subscribers == nil {
+		b.subscribers = make(map[*Subscription]struct{})
+	}
+}
+
+func (b *Broadcaster) Subscribe() *Subscription {
+	s := &Subscription{
+		broadcaster: b,
+		events:      make(chan Event, b.BufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		s.close()
+		return s
+	}
+	b.subscribers[s] = struct{}{}
+	return s
+}
+
+func (b *Broadcaster) Publish(event Event) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delivered := 0
+	for s := range b.subscribers {
+		select {
+		case s.events <- event:
+			delivered++
+		default:
+			delete(b.subscribers, s)
+			s.close()
+		}
+	}
+	return delivered
+}
+
+func (b *Broadcaster) Count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+func (b *Broadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for s := range b.subscribers {
+		delete(b.subscribers, s)
+		s.close()
+	}
+}
+
+func (b *Broadcaster) Handler(opts ...HandlerOption) http.Handler {
+	return NewHandler(b.serve, opts...)
+}
+
+func (b *Broadcaster) serve(stream *Stream) error {
+	s := b.Subscribe()
+	defer s.Close()
+
+	for {
+		select {
+		case <-stream.Done():
+			return nil
+		case event, ok := <-s.Events():
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	b := s.broadcaster
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers, s)
+	s.close()
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() {
+		close(s.events)
+	})
+}
@@ -0,0 +1,107 @@
+package sse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_NewBroadcaster(t *testing.T) {
+	b := NewBroadcaster()
+
+	if b.BufferSize != DefaultBufferSize {
+		t.Errorf("NewBroadcaster() failed: got buffer size %v, expected %v", b.BufferSize, DefaultBufferSize)
+	}
+	if b.Count() != 0 {
+		t.Errorf("NewBroadcaster() failed: got %v subscribers, expected %v", b.Count(), 0)
+	}
+}
+
+func Test_Broadcaster_Publish(t *testing.T) {
+	b := NewBroadcaster()
+	first := b.Subscribe()
+	second := b.Subscribe()
+
+	if delivered := b.Publish(NewEvent("update", "1")); delivered != 2 {
+		t.Errorf("Broadcaster.Publish() failed: got %v delivered, expected %v", delivered, 2)
+	}
+	for _, s := range []*Subscription{first, second} {
+		event := <-s.Events()
+		if event.Data != "1" {
+			t.Errorf("Broadcaster.Publish() failed: got %s, expected %s", event.Data, "1")
+		}
+	}
+
+	first.Close()
+	if b.Count() != 1 {
+		t.Errorf("Subscription.Close() failed: got %v subscribers, expected %v", b.Count(), 1)
+	}
+	if _, ok := <-first.Events(); ok {
+		t.Error("Subscription.Close() failed: events channel not closed")
+	}
+}
+
+func Test_Broadcaster_Publish_SlowSubscriber(t *testing.T) {
+	b := NewBroadcaster(WithBufferSize(1))
+	s := b.Subscribe()
+
+	b.Publish(NewEvent("update", "1"))
+	if delivered := b.Publish(NewEvent("update", "2")); delivered != 0 {
+		t.Errorf("Broadcaster.Publish() failed: got %v delivered, expected %v", delivered, 0)
+	}
+	if b.Count() != 0 {
+		t.Errorf("Broadcaster.Publish() failed: got %v subscribers, expected slow subscriber to be dropped", b.Count())
+	}
+
+	<-s.Events()
+	if _, ok := <-s.Events(); ok {
+		t.Error("Broadcaster.Publish() failed: slow subscriber not closed")
+	}
+	s.Close()
+}
+
+func Test_Broadcaster_Close(t *testing.T) {
+	b := NewBroadcaster()
+	s := b.Subscribe()
+
+	b.Close()
+	if _, ok := <-s.Events(); ok {
+		t.Error("Broadcaster.Close() failed: events channel not closed")
+	}
+	late := b.Subscribe()
+	if _, ok := <-late.Events(); ok {
+		t.Error("Broadcaster.Subscribe() failed: subscribed to closed broadcaster")
+	}
+}
+
+func Test_Broadcaster_Handler(t *testing.T) {
+	b := NewBroadcaster()
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		b.Handler(WithoutHeartbeat()).ServeHTTP(rec, req)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for b.Count() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Broadcaster.Handler() failed: no subscriber registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	b.Publish(NewEvent("update", "1").WithId("1"))
+	b.Close()
+	<-done
+	cancel()
+
+	expected := "id: 1\nevent: update\ndata: 1\n\n"
+	if rec.Body.String() != expected {
+		t.Errorf("Broadcaster.Handler() failed: got %q, expected %q", rec.Body.String(), expected)
+	}
+}
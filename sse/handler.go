@@ -0,0 +1,99 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+type StreamFunc func(s *Stream) error
+type ResumeFunc func(s *Stream, lastEventId string) error
+
+type HandlerOption func(*Handler)
+
+type Handler struct {
+	Stream           StreamFunc
+	Resume           ResumeFunc
+	Heartbeat        time.Duration
+	HeartbeatComment string
+	Retry            time.Duration
+}
+
+func NewHandler(stream StreamFunc, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		Stream: stream,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.EnsureDefaults()
+
+	return h
+}
+
+func (h *Handler) EnsureDefaults() {
+	if h.Heartbeat == 0 {
+		h.Heartbeat = DefaultHeartbeat
+	}
+	if h.HeartbeatComment == "" {
+		h.HeartbeatComment = DefaultHeartbeatComment
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rw := router.NewResponseWriter(w)
+	stream, err := NewStream(rw, r)
+	if err != nil {
+		router.HandleError(rw, r, err)
+		return
+	}
+	defer stream.Close()
+
+	if h.Heartbeat > 0 {
+		go h.heartbeat(stream)
+	}
+	if err := h.serve(stream); err != nil && !isDisconnect(stream, err) {
+		router.HandleError(rw, r, err)
+	}
+}
+
+func (h *Handler) serve(stream *Stream) error {
+	if h.Retry > 0 {
+		if err := stream.Retry(h.Retry); err != nil {
+			return err
+		}
+	}
+	if h.Resume != nil && stream.LastEventId() != "" {
+		if err := h.Resume(stream, stream.LastEventId()); err != nil {
+			return err
+		}
+	}
+	if h.Stream == nil {
+		<-stream.Done()
+		return nil
+	}
+	return h.Stream(stream)
+}
+
+func (h *Handler) heartbeat(stream *Stream) {
+	ticker := time.NewTicker(h.Heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.Done():
+			return
+		case <-ticker.C:
+			if err := stream.Comment(h.HeartbeatComment); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func isDisconnect(stream *Stream, err error) bool {
+	return errors.Is(err, context.Canceled) || stream.Context().Err() != nil
+}
@@ -0,0 +1,159 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/timeout"
+)
+
+func Test_NewHandler(t *testing.T) {
+	h := NewHandler(nil)
+
+	if h.Heartbeat != DefaultHeartbeat {
+		t.Errorf("NewHandler() failed: got heartbeat %v, expected %v", h.Heartbeat, DefaultHeartbeat)
+	}
+	if h.HeartbeatComment != DefaultHeartbeatComment {
+		t.Errorf("NewHandler() failed: got heartbeat comment %s, expected %s", h.HeartbeatComment, DefaultHeartbeatComment)
+	}
+}
+
+func Test_Handler_ServeHTTP(t *testing.T) {
+	middleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(router.NewResponseWriter(w), r)
+		})
+	}
+	r := router.NewRouter()
+	r.Use(router.MiddlewareFunc(middleware))
+	r.Handle("GET /events", NewHandler(func(s *Stream) error {
+		s.SendEvent("update", "1")
+		return nil
+	}, WithRetry(time.Second), WithoutHeartbeat()))
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	expected := "retry: 1000\n\nevent: update\ndata: 1\n\n"
+	if rec.Body.String() != expected {
+		t.Errorf("Handler.ServeHTTP() failed: got %q, expected %q", rec.Body.String(), expected)
+	}
+	if rec.Header().Get("Content-Type") != ContentType {
+		t.Errorf("Handler.ServeHTTP() failed: got content type %s, expected %s", rec.Header().Get("Content-Type"), ContentType)
+	}
+}
+
+func Test_Handler_ServeHTTP_Timeout(t *testing.T) {
+	stream := func(s *Stream) error {
+		if err := s.SendData("1"); err != nil {
+			return err
+		}
+		select {
+		case <-time.After(50 * time.Millisecond):
+		case <-s.Done():
+			return nil
+		}
+		return s.SendData("2")
+	}
+	r := router.NewRouter()
+	timeout.UseMiddleware(r, timeout.WithTimeout(10*time.Millisecond))
+	r.Handle("GET /events", NewHandler(stream, WithoutHeartbeat()), timeout.Disabled())
+	r.Handle("GET /limited", NewHandler(stream, WithoutHeartbeat()))
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"/events", "data: 1\n\ndata: 2\n\n"},
+		{"/limited", "data: 1\n\n"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK || rec.Body.String() != tt.expected {
+			t.Errorf("Handler.ServeHTTP(%s) failed: got %v %q, expected %q", tt.path, rec.Code, rec.Body.String(), tt.expected)
+		}
+	}
+}
+
+func Test_Handler_ServeHTTP_Unsupported(t *testing.T) {
+	h := NewHandler(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(nonFlusherMock{rec}, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Handler.ServeHTTP() failed: got %v, expected %v", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func Test_Handler_ServeHTTP_Resume(t *testing.T) {
+	resumed := ""
+	h := NewHandler(func(s *Stream) error {
+		return s.SendData("live")
+	}, WithResume(func(s *Stream, lastEventId string) error {
+		resumed = lastEventId
+		return s.Send(NewEvent("missed", "x").WithId("43"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set(HeaderLastEventId, "42")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if resumed != "42" {
+		t.Errorf("Handler.ServeHTTP() failed: got last event id %s, expected %s", resumed, "42")
+	}
+	expected := "id: 43\nevent: missed\ndata: x\n\ndata: live\n\n"
+	if rec.Body.String() != expected {
+		t.Errorf("Handler.ServeHTTP() failed: got %q, expected %q", rec.Body.String(), expected)
+	}
+}
+
+func Test_Handler_ServeHTTP_Heartbeat(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	h := NewHandler(func(s *Stream) error {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		<-s.Done()
+		return s.Context().Err()
+	}, WithHeartbeat(10*time.Millisecond), WithHeartbeatComment("ping"))
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), ": ping\n\n") {
+		t.Errorf("Handler.ServeHTTP() failed: got %q, expected heartbeat comments", rec.Body.String())
+	}
+}
+
+func Test_Handler_ServeHTTP_Error(t *testing.T) {
+	handled := make([]error, 0)
+	r := router.NewRouter()
+	r.SetErrorHandler(router.ErrorHandlerFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+		handled = append(handled, err)
+	}))
+	streamErr := errors.New("stream failed")
+	r.Handle("GET /events", NewHandler(func(s *Stream) error {
+		return streamErr
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if len(handled) != 1 || !errors.Is(handled[0], streamErr) {
+		t.Errorf("Handler.ServeHTTP() failed: got %v, expected %v", handled, streamErr)
+	}
+}
@@ -0,0 +1,39 @@
+package sse
+
+import (
+	"time"
+)
+
+func WithResume(resume ResumeFunc) HandlerOption {
+	return func(h *Handler) {
+		h.Resume = resume
+	}
+}
+
+func WithHeartbeat(d time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.Heartbeat = d
+	}
+}
+
+func WithoutHeartbeat() HandlerOption {
+	return WithHeartbeat(-1)
+}
+
+func WithHeartbeatComment(comment string) HandlerOption {
+	return func(h *Handler) {
+		h.HeartbeatComment = comment
+	}
+}
+
+func WithRetry(d time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.Retry = d
+	}
+}
+
+func WithBufferSize(size int) BroadcasterOption {
+	return func(b *Broadcaster) {
+		b.BufferSize = size
+	}
+}
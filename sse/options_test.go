@@ -0,0 +1,69 @@
+package sse
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_WithResume(t *testing.T) {
+	h := &Handler{}
+	option := WithResume(func(s *Stream, lastEventId string) error {
+		return nil
+	})
+	option(h)
+
+	if h.Resume == nil {
+		t.Error("WithResume() failed: resume not set")
+	}
+}
+
+func Test_WithHeartbeat(t *testing.T) {
+	h := &Handler{}
+	option := WithHeartbeat(time.Second)
+	option(h)
+
+	if h.Heartbeat != time.Second {
+		t.Errorf("WithHeartbeat() failed: got %v, expected %v", h.Heartbeat, time.Second)
+	}
+}
+
+func Test_WithoutHeartbeat(t *testing.T) {
+	h := &Handler{}
+	option := WithoutHeartbeat()
+	option(h)
+	h.EnsureDefaults()
+
+	if h.Heartbeat >= 0 {
+		t.Errorf("WithoutHeartbeat() failed: got %v, expected heartbeat to be disabled", h.Heartbeat)
+	}
+}
+
+func Test_WithHeartbeatComment(t *testing.T) {
+	h := &Handler{}
+	option := WithHeartbeatComment("ping")
+	option(h)
+
+	if h.HeartbeatComment != "ping" {
+		t.Errorf("WithHeartbeatComment() failed: got %s, expected %s", h.HeartbeatComment, "ping")
+	}
+}
+
+func Test_WithRetry(t *testing.T) {
+	h := &Handler{}
+	option := WithRetry(time.Second)
+	option(h)
+
+	if h.Retry != time.Second {
+		t.Errorf("WithRetry() failed: got %v, expected %v", h.Retry, time.Second)
+	}
+}
+
+func Test_WithBufferSize(t *testing.T) {
+	b := &Broadcaster{}
+	option := WithBufferSize(4)
+	option(b)
+
+	if b.BufferSize != 4 {
+		t.Errorf("WithBufferSize() failed: got %v, expected %v", b.BufferSize, 4)
+	}
+}
@@ -0,0 +1,89 @@
+package sse
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ContentType       string = "text/event-stream"
+	HeaderLastEventId string = "Last-Event-ID"
+
+	DefaultHeartbeat        time.Duration = 15 * time.Second
+	DefaultHeartbeatComment string        = "heartbeat"
+)
+
+type Event struct {
+	Id      string
+	Event   string
+	Data    string
+	Retry   time.Duration
+	Comment string
+}
+
+func NewEvent(event string, data string) Event {
+	return Event{
+		Event: event,
+		Data:  data,
+	}
+}
+
+func NewJsonEvent(event string, v any) (Event, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Event{}, err
+	}
+	return NewEvent(event, string(data)), nil
+}
+
+func (e Event) WithId(id string) Event {
+	e.Id = id
+	return e
+}
+
+func (e Event) WriteTo(w io.Writer) (int64, error) {
+	var buffer bytes.Buffer
+	if e.Comment != "" {
+		for _, line := range splitLines(e.Comment) {
+			buffer.WriteString(": " + line + "\n")
+		}
+	}
+	if e.Id != "" {
+		buffer.WriteString("id: " + sanitize(e.Id) + "\n")
+	}
+	if e.Event != "" {
+		buffer.WriteString("event: " + sanitize(e.Event) + "\n")
+	}
+	if e.Retry > 0 {
+		buffer.WriteString("retry: " + strconv.FormatInt(e.Retry.Milliseconds(), 10) + "\n")
+	}
+	if e.Data != "" || (e.Id == "" && e.Event == "" && e.Retry <= 0 && e.Comment == "") {
+		for _, line := range splitLines(e.Data) {
+			buffer.WriteString("data: " + line + "\n")
+		}
+	}
+	buffer.WriteString("\n")
+
+	n, err := w.Write(buffer.Bytes())
+	return int64(n), err
+}
+
+func (e Event) String() string {
+	var builder strings.Builder
+	e.WriteTo(&builder)
+	return builder.String()
+}
+
+func splitLines(value string) []string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	value = strings.ReplaceAll(value, "\r", "\n")
+	return strings.Split(value, "\n")
+}
+
+func sanitize(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "", "\x00", "").Replace(value)
+}
@@ -0,0 +1,50 @@
+package sse
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Event_String(t *testing.T) {
+	type testCase struct {
+		event    Event
+		expected string
+	}
+	tests := []testCase{
+		{Event{Data: "hello"}, "data: hello\n\n"},
+		{Event{Data: "line1\nline2\r\nline3"}, "data: line1\ndata: line2\ndata: line3\n\n"},
+		{Event{Id: "1", Event: "update", Data: "{}"}, "id: 1\nevent: update\ndata: {}\n\n"},
+		{Event{Id: "1\n2", Event: "up\rdate", Data: "x"}, "id: 12\nevent: update\ndata: x\n\n"},
+		{Event{Retry: 3 * time.Second}, "retry: 3000\n\n"},
+		{Event{Comment: "heartbeat"}, ": heartbeat\n\n"},
+		{Event{}, "data: \n\n"},
+	}
+
+	for _, tc := range tests {
+		result := tc.event.String()
+		if result != tc.expected {
+			t.Errorf("Event.String() failed: got %q, expected %q", result, tc.expected)
+		}
+	}
+}
+
+func Test_NewJsonEvent(t *testing.T) {
+	event, err := NewJsonEvent("order", map[string]int{"id": 1})
+	if err != nil {
+		t.Fatalf("NewJsonEvent() failed: %v", err)
+	}
+	if event.Event != "order" || event.Data != `{"id":1}` {
+		t.Errorf("NewJsonEvent() failed: got %+v, expected order event with json data", event)
+	}
+
+	if _, err := NewJsonEvent("invalid", make(chan int)); err == nil {
+		t.Error("NewJsonEvent() failed: expected error for unsupported value")
+	}
+}
+
+func Test_Event_WithId(t *testing.T) {
+	event := NewEvent("update", "data").WithId("42")
+	if event.Id != "42" {
+		t.Errorf("Event.WithId() failed: got %s, expected %s", event.Id, "42")
+	}
+}
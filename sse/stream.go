@@ -0,0 +1,134 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrStreamingUnsupported = errors.New("streaming not supported")
+	ErrStreamClosed         = errors.New("stream closed")
+)
+
+type Stream struct {
+	writer      http.ResponseWriter
+	controller  *http.ResponseController
+	ctx         context.Context
+	cancel      context.CancelCauseFunc
+	mu          sync.Mutex
+	lastEventId string
+}
+
+func NewStream(w http.ResponseWriter, r *http.Request) (*Stream, error) {
+	if !canFlush(w) {
+		return nil, ErrStreamingUnsupported
+	}
+	controller := http.NewResponseController(w)
+
+	header := w.Header()
+	header.Set("Content-Type", ContentType)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	header.Del("Content-Length")
+	if r.ProtoMajor == 1 {
+		header.Set("Connection", "keep-alive")
+	}
+
+	controller.SetWriteDeadline(time.Time{})
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancelCause(r.Context())
+	return &Stream{
+		writer:      w,
+		controller:  controller,
+		ctx:         ctx,
+		cancel:      cancel,
+		lastEventId: r.Header.Get(HeaderLastEventId),
+	}, nil
+}
+
+func (s *Stream) Send(event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		return s.err()
+	}
+	if _, err := event.WriteTo(s.writer); err != nil {
+		s.cancel(err)
+		return err
+	}
+	if err := s.controller.Flush(); err != nil {
+		s.cancel(err)
+		return err
+	}
+	return nil
+}
+
+func (s *Stream) SendData(data string) error {
+	return s.Send(Event{Data: data})
+}
+
+func (s *Stream) SendEvent(event string, data string) error {
+	return s.Send(NewEvent(event, data))
+}
+
+func (s *Stream) SendJson(event string, v any) error {
+	e, err := NewJsonEvent(event, v)
+	if err != nil {
+		return err
+	}
+	return s.Send(e)
+}
+
+func (s *Stream) Comment(comment string) error {
+	return s.Send(Event{Comment: comment})
+}
+
+func (s *Stream) Retry(d time.Duration) error {
+	return s.Send(Event{Retry: d})
+}
+
+func (s *Stream) LastEventId() string {
+	return s.lastEventId
+}
+
+func (s *Stream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *Stream) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancel(ErrStreamClosed)
+}
+
+func (s *Stream) err() error {
+	if cause := context.Cause(s.ctx); cause != nil {
+		return cause
+	}
+	return ErrStreamClosed
+}
+
+func canFlush(w http.ResponseWriter) bool {
+	for {
+		if _, ok := w.(http.Flusher); ok {
+			return true
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return false
+		}
+		w = unwrapper.Unwrap()
+	}
+}
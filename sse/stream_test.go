@@ -0,0 +1,89 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type nonFlusherMock struct {
+	http.ResponseWriter
+}
+
+func Test_NewStream(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set(HeaderLastEventId, "41")
+	rec := httptest.NewRecorder()
+
+	stream, err := NewStream(rec, req)
+	if err != nil {
+		t.Fatalf("NewStream() failed: %v", err)
+	}
+	if rec.Header().Get("Content-Type") != ContentType {
+		t.Errorf("NewStream() failed: got content type %s, expected %s", rec.Header().Get("Content-Type"), ContentType)
+	}
+	if rec.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("NewStream() failed: got cache control %s, expected %s", rec.Header().Get("Cache-Control"), "no-cache")
+	}
+	if !rec.Flushed {
+		t.Error("NewStream() failed: headers not flushed")
+	}
+	if stream.LastEventId() != "41" {
+		t.Errorf("NewStream() failed: got last event id %s, expected %s", stream.LastEventId(), "41")
+	}
+}
+
+func Test_NewStream_Unsupported(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := httptest.NewRecorder()
+
+	_, err := NewStream(nonFlusherMock{rec}, req)
+	if !errors.Is(err, ErrStreamingUnsupported) {
+		t.Errorf("NewStream() failed: got %v, expected %v", err, ErrStreamingUnsupported)
+	}
+	if rec.Header().Get("Content-Type") == ContentType {
+		t.Error("NewStream() failed: headers set on unsupported writer")
+	}
+}
+
+func Test_Stream_Send(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := httptest.NewRecorder()
+	stream, _ := NewStream(rec, req)
+
+	stream.SendEvent("update", "1")
+	stream.SendData("2")
+	stream.SendJson("order", map[string]int{"id": 3})
+	stream.Comment("ping")
+
+	expected := "event: update\ndata: 1\n\ndata: 2\n\nevent: order\ndata: {\"id\":3}\n\n: ping\n\n"
+	if rec.Body.String() != expected {
+		t.Errorf("Stream.Send() failed: got %q, expected %q", rec.Body.String(), expected)
+	}
+}
+
+func Test_Stream_Disconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	stream, _ := NewStream(rec, req)
+
+	cancel()
+	<-stream.Done()
+	if err := stream.SendData("lost"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Stream.Send() failed: got %v, expected %v", err, context.Canceled)
+	}
+}
+
+func Test_Stream_Close(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	rec := httptest.NewRecorder()
+	stream, _ := NewStream(rec, req)
+
+	stream.Close()
+	if err := stream.SendData("lost"); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("Stream.Send() failed: got %v, expected %v", err, ErrStreamClosed)
+	}
+}
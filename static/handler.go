@@ -0,0 +1,199 @@
+package static
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/compression"
+)
+
+type HandlerOption func(*Handler)
+
+type Handler struct {
+	FS                  fs.FS
+	Index               string
+	Listing             bool
+	Precompressed       bool
+	SpaFallback         bool
+	ExcludedPrefixes    []string
+	CacheControl        map[string]string
+	DefaultCacheControl string
+}
+
+func NewHandler(fsys fs.FS, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		FS: fsys,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.EnsureDefaults()
+
+	return h
+}
+
+func (h *Handler) EnsureDefaults() {
+	if h.Index == "" {
+		h.Index = DefaultIndex
+	}
+	if h.CacheControl == nil {
+		h.CacheControl = make(map[string]string)
+	}
+	if h.ExcludedPrefixes == nil {
+		h.ExcludedPrefixes = make([]string, 0)
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Clean("/" + router.Params(r)[ParamName])[1:]
+	if name == "" {
+		name = "."
+	}
+	if !fs.ValidPath(name) {
+		router.Error(w, r, router.NewProblem(http.StatusNotFound, ""))
+		return
+	}
+
+	info, err := fs.Stat(h.FS, name)
+	if err == nil && info.IsDir() {
+		index := path.Join(name, h.Index)
+		if indexInfo, err := fs.Stat(h.FS, index); err == nil && !indexInfo.IsDir() {
+			h.serveFile(w, r, index, indexInfo)
+			return
+		}
+		if h.Listing {
+			h.serveListing(w, r, name)
+			return
+		}
+		err = fs.ErrNotExist
+	}
+	if err == nil {
+		h.serveFile(w, r, name, info)
+		return
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		router.Error(w, r, router.NewProblem(http.StatusInternalServerError, ""))
+		return
+	}
+
+	if h.isFallback(r, name) {
+		if indexInfo, err := fs.Stat(h.FS, h.Index); err == nil && !indexInfo.IsDir() {
+			w.Header().Set("Cache-Control", "no-cache")
+			h.serveFile(w, r, h.Index, indexInfo)
+			return
+		}
+	}
+	router.Error(w, r, router.NewProblem(http.StatusNotFound, ""))
+}
+
+func (h *Handler) serveListing(w http.ResponseWriter, r *http.Request, name string) {
+	entries, err := fs.ReadDir(h.FS, name)
+	if err != nil {
+		router.Error(w, r, router.NewProblem(http.StatusInternalServerError, ""))
+		return
+	}
+
+	base := strings.TrimSuffix(r.URL.Path, "/") + "/"
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<!doctype html>\n<pre>\n")
+	for _, entry := range entries {
+		entryName := entry.Name()
+		if entry.IsDir() {
+			entryName += "/"
+		}
+		link := url.URL{Path: base + entryName}
+		fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", html.EscapeString(link.String()), html.EscapeString(entryName))
+	}
+	fmt.Fprintf(w, "</pre>\n")
+}
+
+func (h *Handler) isFallback(r *http.Request, name string) bool {
+	if !h.SpaFallback || hasExtension(name) || !acceptsHtml(r) || hasRoutes(r, name) {
+		return false
+	}
+	for _, prefix := range h.ExcludedPrefixes {
+		prefix = strings.Trim(prefix, "/")
+		if name == prefix || strings.HasPrefix(name, prefix+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Handler) serveFile(w http.ResponseWriter, r *http.Request, name string, info fs.FileInfo) {
+	header := w.Header()
+	if header.Get("Cache-Control") == "" {
+		if cacheControl := h.getCacheControl(name); cacheControl != "" {
+			header.Set("Cache-Control", cacheControl)
+		}
+	}
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+
+	if h.Precompressed {
+		header.Add("Vary", "Accept-Encoding")
+		if variant, variantInfo, ok := h.findVariant(r, name); ok {
+			header.Set("Content-Encoding", variant.encoding)
+			h.serveContent(w, r, name+variant.extension, variantInfo.ModTime())
+			return
+		}
+	}
+	h.serveContent(w, r, name, info.ModTime())
+}
+
+func (h *Handler) findVariant(r *http.Request, name string) (encodingVariant, fs.FileInfo, bool) {
+	available := make([]string, 0)
+	infos := make(map[string]fs.FileInfo)
+	for _, variant := range precompressedVariants {
+		if info, err := fs.Stat(h.FS, name+variant.extension); err == nil && !info.IsDir() {
+			available = append(available, variant.encoding)
+			infos[variant.encoding] = info
+		}
+	}
+	encoding := compression.NegotiateEncoding(r.Header.Get("Accept-Encoding"), available)
+	for _, variant := range precompressedVariants {
+		if variant.encoding == encoding {
+			return variant, infos[encoding], true
+		}
+	}
+	return encodingVariant{}, nil, false
+}
+
+func (h *Handler) serveContent(w http.ResponseWriter, r *http.Request, name string, modTime time.Time) {
+	file, err := h.FS.Open(name)
+	if err != nil {
+		router.Error(w, r, router.NewProblem(http.StatusNotFound, ""))
+		return
+	}
+	defer file.Close()
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			router.Error(w, r, router.NewProblem(http.StatusInternalServerError, ""))
+			return
+		}
+		content = bytes.NewReader(data)
+	}
+	http.ServeContent(w, r, path.Base(name), modTime, content)
+}
+
+func (h *Handler) getCacheControl(name string) string {
+	if cacheControl, ok := h.CacheControl[strings.ToLower(path.Ext(name))]; ok {
+		return cacheControl
+	}
+	return h.DefaultCacheControl
+}
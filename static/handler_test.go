@@ -0,0 +1,192 @@
+package static
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/deb-ict/go-router"
+)
+
+func newHandlerRequest(method string, name string) *http.Request {
+	req := httptest.NewRequest(method, "/"+name, nil)
+	var paramsContextKey router.ContextKey = "router::params"
+	ctx := context.WithValue(req.Context(), paramsContextKey, router.RouteParams{ParamName: name})
+	return req.WithContext(ctx)
+}
+
+func Test_NewHandler(t *testing.T) {
+	h := NewHandler(fstest.MapFS{})
+
+	if h.Index != DefaultIndex {
+		t.Errorf("NewHandler() failed: got index %s, expected %s", h.Index, DefaultIndex)
+	}
+	if h.CacheControl == nil {
+		t.Error("NewHandler() failed: Cache control rules not initialized")
+	}
+	if h.Listing {
+		t.Error("NewHandler() failed: Directory listing enabled by default")
+	}
+}
+
+func Test_Handler_ServeHTTP_Range(t *testing.T) {
+	h := NewHandler(fstest.MapFS{"data.txt": {Data: []byte("0123456789")}})
+
+	req := newHandlerRequest(http.MethodGet, "data.txt")
+	req.Header.Set("Range", "bytes=2-5")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusPartialContent {
+		t.Errorf("ServeHTTP() failed: got status %v, expected %v", rec.Code, http.StatusPartialContent)
+	}
+	if rec.Body.String() != "2345" {
+		t.Errorf("ServeHTTP() failed: got %s, expected %s", rec.Body.String(), "2345")
+	}
+}
+
+func Test_Handler_ServeHTTP_Precompressed(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.js":    {Data: []byte("plain")},
+		"app.js.gz": {Data: []byte("gzip")},
+		"app.js.br": {Data: []byte("brotli")},
+	}
+	h := NewHandler(fsys, WithPrecompressed(true))
+
+	tests := []struct {
+		acceptEncoding string
+		encoding       string
+		expected       string
+	}{
+		{"", "", "plain"},
+		{"gzip", "gzip", "gzip"},
+		{"gzip, br", "br", "brotli"},
+		{"br;q=0.5, gzip", "gzip", "gzip"},
+		{"deflate", "", "plain"},
+	}
+	for _, tt := range tests {
+		req := newHandlerRequest(http.MethodGet, "app.js")
+		req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Body.String() != tt.expected {
+			t.Errorf("ServeHTTP(%s) failed: got %s, expected %s", tt.acceptEncoding, rec.Body.String(), tt.expected)
+		}
+		if rec.Header().Get("Content-Encoding") != tt.encoding {
+			t.Errorf("ServeHTTP(%s) failed: got encoding %s, expected %s", tt.acceptEncoding, rec.Header().Get("Content-Encoding"), tt.encoding)
+		}
+		if rec.Header().Get("Content-Type") != "text/javascript; charset=utf-8" {
+			t.Errorf("ServeHTTP(%s) failed: got content type %s, expected %s", tt.acceptEncoding, rec.Header().Get("Content-Type"), "text/javascript; charset=utf-8")
+		}
+		if rec.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("ServeHTTP(%s) failed: Vary header not set", tt.acceptEncoding)
+		}
+	}
+}
+
+func Test_Handler_ServeHTTP_CacheControl(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.js":     {Data: []byte("app")},
+		"index.html": {Data: []byte("index")},
+		"logo.svg":   {Data: []byte("<svg/>")},
+	}
+	h := NewHandler(fsys,
+		WithCacheControl(".js", "public, max-age=31536000, immutable"),
+		WithCacheControl("html", "no-cache"),
+		WithDefaultCacheControl("public, max-age=3600"),
+	)
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"app.js", "public, max-age=31536000, immutable"},
+		{"index.html", "no-cache"},
+		{"logo.svg", "public, max-age=3600"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newHandlerRequest(http.MethodGet, tt.name))
+
+		if rec.Header().Get("Cache-Control") != tt.expected {
+			t.Errorf("ServeHTTP(%s) failed: got %s, expected %s", tt.name, rec.Header().Get("Cache-Control"), tt.expected)
+		}
+	}
+}
+
+func Test_Handler_ServeHTTP_Directory(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/readme.txt":  {Data: []byte("readme")},
+		"guide/index.html": {Data: []byte("guide")},
+	}
+
+	tests := []struct {
+		name     string
+		listing  bool
+		status   int
+		expected string
+	}{
+		{"docs", false, http.StatusNotFound, ""},
+		{"docs", true, http.StatusOK, "<a href=\"/docs/readme.txt\">readme.txt</a>"},
+		{"guide", false, http.StatusOK, "guide"},
+	}
+	for _, tt := range tests {
+		h := NewHandler(fsys, WithDirectoryListing(tt.listing))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newHandlerRequest(http.MethodGet, tt.name))
+
+		if rec.Code != tt.status {
+			t.Errorf("ServeHTTP(%s, %v) failed: got status %v, expected %v", tt.name, tt.listing, rec.Code, tt.status)
+		}
+		if tt.expected != "" && !strings.Contains(rec.Body.String(), tt.expected) {
+			t.Errorf("ServeHTTP(%s, %v) failed: got %s, expected %s", tt.name, tt.listing, rec.Body.String(), tt.expected)
+		}
+	}
+}
+
+func Test_Handler_ServeHTTP_Traversal(t *testing.T) {
+	h := NewHandler(fstest.MapFS{"index.html": {Data: []byte("index")}})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, newHandlerRequest(http.MethodGet, "../../etc/passwd"))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("ServeHTTP() failed: got status %v, expected %v", rec.Code, http.StatusNotFound)
+	}
+}
+
+func Test_Handler_isFallback(t *testing.T) {
+	h := NewHandler(fstest.MapFS{}, WithSpaFallback("/api", "metrics"))
+
+	tests := []struct {
+		name     string
+		accept   string
+		expected bool
+	}{
+		{"orders/12", "text/html,application/xhtml+xml", true},
+		{"orders/12", "", false},
+		{"orders/12", "*/*", false},
+		{"orders/12", "text/html;q=0, */*", false},
+		{"orders/12", "application/json;q=0.9, TEXT/HTML;q=0.8", true},
+		{"orders/12", "application/json", false},
+		{"app.js", "text/html", false},
+		{"api/orders", "text/html", false},
+		{"api", "text/html", false},
+		{"apis/orders", "text/html", true},
+		{"metrics", "text/html", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		result := h.isFallback(req, tt.name)
+		if result != tt.expected {
+			t.Errorf("isFallback(%s, %s) failed: got %v, expected %v", tt.name, tt.accept, result, tt.expected)
+		}
+	}
+}
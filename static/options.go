@@ -0,0 +1,48 @@
+package static
+
+import (
+	"strings"
+)
+
+func WithIndex(name string) HandlerOption {
+	return func(h *Handler) {
+		h.Index = name
+	}
+}
+
+func WithDirectoryListing(enabled bool) HandlerOption {
+	return func(h *Handler) {
+		h.Listing = enabled
+	}
+}
+
+func WithPrecompressed(enabled bool) HandlerOption {
+	return func(h *Handler) {
+		h.Precompressed = enabled
+	}
+}
+
+func WithSpaFallback(excludedPrefixes ...string) HandlerOption {
+	return func(h *Handler) {
+		h.SpaFallback = true
+		h.ExcludedPrefixes = append(h.ExcludedPrefixes, excludedPrefixes...)
+	}
+}
+
+func WithCacheControl(extension string, value string) HandlerOption {
+	return func(h *Handler) {
+		if h.CacheControl == nil {
+			h.CacheControl = make(map[string]string)
+		}
+		if !strings.HasPrefix(extension, ".") {
+			extension = "." + extension
+		}
+		h.CacheControl[strings.ToLower(extension)] = value
+	}
+}
+
+func WithDefaultCacheControl(value string) HandlerOption {
+	return func(h *Handler) {
+		h.DefaultCacheControl = value
+	}
+}
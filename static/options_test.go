@@ -0,0 +1,71 @@
+package static
+
+import (
+	"testing"
+)
+
+func Test_WithIndex(t *testing.T) {
+	expected := "default.htm"
+	h := &Handler{}
+	option := WithIndex(expected)
+	option(h)
+
+	if h.Index != expected {
+		t.Errorf("WithIndex() failed: got %s, expected %s", h.Index, expected)
+	}
+}
+
+func Test_WithDirectoryListing(t *testing.T) {
+	h := &Handler{}
+	option := WithDirectoryListing(true)
+	option(h)
+
+	if !h.Listing {
+		t.Errorf("WithDirectoryListing() failed: got %v, expected %v", h.Listing, true)
+	}
+}
+
+func Test_WithPrecompressed(t *testing.T) {
+	h := &Handler{}
+	option := WithPrecompressed(true)
+	option(h)
+
+	if !h.Precompressed {
+		t.Errorf("WithPrecompressed() failed: got %v, expected %v", h.Precompressed, true)
+	}
+}
+
+func Test_WithSpaFallback(t *testing.T) {
+	h := &Handler{}
+	option := WithSpaFallback("/api", "/metrics")
+	option(h)
+
+	if !h.SpaFallback {
+		t.Errorf("WithSpaFallback() failed: got %v, expected %v", h.SpaFallback, true)
+	}
+	if len(h.ExcludedPrefixes) != 2 {
+		t.Errorf("WithSpaFallback() failed: got %v excluded prefixes, expected %v", len(h.ExcludedPrefixes), 2)
+	}
+}
+
+func Test_WithCacheControl(t *testing.T) {
+	expected := "no-cache"
+	h := &Handler{}
+	option := WithCacheControl("HTML", expected)
+	option(h)
+
+	if h.CacheControl[".html"] != expected {
+		t.Errorf("WithCacheControl() failed: got %s, expected %s", h.CacheControl[".html"], expected)
+	}
+}
+
+func Test_WithDefaultCacheControl(t *testing.T) {
+	expected := "public, max-age=3600"
+	h := &Handler{}
+	option := WithDefaultCacheControl(expected)
+	option(h)
+
+	if h.DefaultCacheControl != expected {
+		t.Errorf("WithDefaultCacheControl() failed: got %s, expected %s", h.DefaultCacheControl, expected)
+	}
+}
@@ -0,0 +1,78 @@
+package static
+
+import (
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	ParamName    string = "filepath"
+	DefaultIndex string = "index.html"
+)
+
+type encodingVariant struct {
+	encoding  string
+	extension string
+}
+
+var precompressedVariants = []encodingVariant{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+func Serve(r *router.Router, prefix string, fsys fs.FS, opts ...HandlerOption) *router.Route {
+	h := NewHandler(fsys, opts...)
+
+	pattern := "/" + strings.Trim(prefix, "/") + "/{" + ParamName + "...}"
+	if strings.Trim(prefix, "/") == "" {
+		pattern = "/{" + ParamName + "...}"
+	}
+	return r.Handle(pattern, h).AllowedMethods(http.MethodGet, http.MethodHead)
+}
+
+func ServeDir(r *router.Router, prefix string, dir string, opts ...HandlerOption) *router.Route {
+	return Serve(r, prefix, os.DirFS(dir), opts...)
+}
+
+func hasExtension(name string) bool {
+	base := name[strings.LastIndex(name, "/")+1:]
+	return strings.Contains(base, ".")
+}
+
+func acceptsHtml(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(mediaType), "text/html") {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "q") {
+				if quality, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil || quality <= 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func hasRoutes(r *http.Request, name string) bool {
+	route := router.CurrentRoute(r)
+	if route == nil || route.GetNode() == nil || route.GetNode().Parent == nil {
+		return false
+	}
+	segment, _, _ := strings.Cut(strings.ToLower(name), "/")
+	for _, node := range route.GetNode().Parent.Nodes {
+		if node.Type == router.NodeTypePath && node.Segment == segment {
+			return true
+		}
+	}
+	return false
+}
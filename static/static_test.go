@@ -0,0 +1,158 @@
+package static
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_Serve(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":    {Data: []byte("<html>index</html>")},
+		"assets/app.js": {Data: []byte("console.log('app')")},
+	}
+	r := router.NewRouter()
+	r.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("users"))
+	}).AllowedMethod(http.MethodGet)
+	Serve(r, "/", fsys, WithSpaFallback("/api"))
+
+	tests := []struct {
+		path     string
+		accept   string
+		status   int
+		expected string
+	}{
+		{"/", "", http.StatusOK, "<html>index</html>"},
+		{"/assets/app.js", "", http.StatusOK, "console.log('app')"},
+		{"/api/users", "", http.StatusOK, "users"},
+		{"/orders/12", "text/html", http.StatusOK, "<html>index</html>"},
+		{"/orders/12", "application/json", http.StatusNotFound, ""},
+		{"/assets/missing.js", "text/html", http.StatusNotFound, ""},
+		{"/api/orders", "text/html", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("Serve(%s) failed: got status %v, expected %v", tt.path, rec.Code, tt.status)
+		}
+		if tt.expected != "" && rec.Body.String() != tt.expected {
+			t.Errorf("Serve(%s) failed: got %s, expected %s", tt.path, rec.Body.String(), tt.expected)
+		}
+	}
+}
+
+func Test_Serve_RegisteredPrefix(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":     {Data: []byte("index")},
+		"account.html":   {Data: []byte("account")},
+		"api/index.html": {Data: []byte("api docs")},
+	}
+	r := router.NewRouter()
+	r.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("users"))
+	}).AllowedMethod(http.MethodGet)
+	r.HandleFunc("/account/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("account"))
+	}).AllowedMethod(http.MethodGet)
+	Serve(r, "/", fsys, WithSpaFallback())
+
+	tests := []struct {
+		path     string
+		accept   string
+		status   int
+		expected string
+	}{
+		{"/api", "", http.StatusOK, "api docs"},
+		{"/api/users/12", "text/html", http.StatusNotFound, ""},
+		{"/api/orders", "text/html", http.StatusNotFound, ""},
+		{"/account", "text/html", http.StatusNotFound, ""},
+		{"/account.html", "", http.StatusOK, "account"},
+		{"/orders", "text/html", http.StatusOK, "index"},
+		{"/orders", "*/*", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("Serve(%s) failed: got status %v, expected %v", tt.path, rec.Code, tt.status)
+		}
+		if tt.expected != "" && rec.Body.String() != tt.expected {
+			t.Errorf("Serve(%s) failed: got %s, expected %s", tt.path, rec.Body.String(), tt.expected)
+		}
+	}
+}
+
+func Test_Serve_Prefix(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html": {Data: []byte("index")},
+		"app.css":    {Data: []byte("body{}")},
+	}
+	r := router.NewRouter()
+	Serve(r, "/static", fsys)
+
+	tests := []struct {
+		path     string
+		status   int
+		expected string
+	}{
+		{"/static", http.StatusOK, "index"},
+		{"/static/app.css", http.StatusOK, "body{}"},
+		{"/app.css", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("Serve(%s) failed: got status %v, expected %v", tt.path, rec.Code, tt.status)
+		}
+		if tt.expected != "" && rec.Body.String() != tt.expected {
+			t.Errorf("Serve(%s) failed: got %s, expected %s", tt.path, rec.Body.String(), tt.expected)
+		}
+	}
+}
+
+func Test_Serve_MethodNotAllowed(t *testing.T) {
+	r := router.NewRouter()
+	Serve(r, "/", fstest.MapFS{"index.html": {Data: []byte("index")}})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/index.html", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Serve() failed: got status %v, expected %v", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func Test_hasExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"app.js", true},
+		{"assets/app.js", true},
+		{"orders/12", false},
+		{"v1.0/orders", false},
+	}
+	for _, tt := range tests {
+		result := hasExtension(tt.name)
+		if result != tt.expected {
+			t.Errorf("hasExtension(%s) failed: got %v, expected %v", tt.name, result, tt.expected)
+		}
+	}
+}
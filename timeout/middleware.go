@@ -0,0 +1,90 @@
+package timeout
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	Timeout        time.Duration
+	StatusCode     int
+	Message        string
+	TimeoutHandler http.Handler
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d := m.Timeout
+		if routeTimeout, ok := GetRouteTimeout(router.CurrentRoute(r)); ok {
+			d = routeTimeout
+		}
+		if d <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		tw, rw := newTimeoutWriter(ctx, w)
+		done := make(chan struct{})
+		panicChan := make(chan any, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicChan <- p
+				}
+			}()
+			next.ServeHTTP(rw, r)
+			close(done)
+		}()
+
+		select {
+		case p := <-panicChan:
+			panic(p)
+		case <-done:
+			tw.flush()
+		case <-ctx.Done():
+			if tw.timeout() && ctx.Err() == context.DeadlineExceeded {
+				m.TimeoutHandler.ServeHTTP(w, r)
+			}
+		}
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.StatusCode == 0 {
+		m.StatusCode = DefaultStatusCode
+	}
+	if m.Message == "" {
+		m.Message = http.StatusText(m.StatusCode)
+	}
+	if m.TimeoutHandler == nil {
+		m.TimeoutHandler = http.HandlerFunc(m.serveTimeout)
+	}
+}
+
+func (m *Middleware) serveTimeout(w http.ResponseWriter, r *http.Request) {
+	router.Error(w, r, router.NewProblem(m.StatusCode, m.Message))
+}
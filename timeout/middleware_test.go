@@ -0,0 +1,215 @@
+package timeout
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("NewMiddleware() failed: got status code %v, expected %v", m.StatusCode, http.StatusServiceUnavailable)
+		}
+		if m.TimeoutHandler == nil {
+			t.Error("NewMiddleware() failed: Default timeout handler not set")
+		}
+	}
+}
+
+func Test_NewMiddleware_Message(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   string
+	}{
+		{0, "Service Unavailable"},
+		{http.StatusGatewayTimeout, "Gateway Timeout"},
+	}
+	for _, tt := range tests {
+		m := NewMiddleware(WithStatusCode(tt.statusCode))
+		if m.Message != tt.expected {
+			t.Errorf("NewMiddleware(%v) failed: got message %s, expected %s", tt.statusCode, m.Message, tt.expected)
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware_Completed(t *testing.T) {
+	m := NewMiddleware(WithTimeout(time.Second))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Error("Middleware(completed) failed: context deadline not set")
+		}
+		w.Header().Set("X-Test", "ok")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("done"))
+	}))
+
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rsp.Code != http.StatusCreated {
+		t.Errorf("Middleware(completed) failed: got status %v, expected %v", rsp.Code, http.StatusCreated)
+	}
+	if rsp.Body.String() != "done" {
+		t.Errorf("Middleware(completed) failed: got body %v, expected done", rsp.Body.String())
+	}
+	if rsp.Header().Get("X-Test") != "ok" {
+		t.Error("Middleware(completed) failed: header not copied")
+	}
+}
+
+func Test_Middleware_TimedOut(t *testing.T) {
+	lateWrite := make(chan error, 1)
+	m := NewMiddleware(
+		WithTimeout(10*time.Millisecond),
+		WithStatusCode(http.StatusGatewayTimeout),
+		WithMessage("too slow"),
+	)
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		_, err := w.Write([]byte("late"))
+		lateWrite <- err
+	}))
+
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rsp.Code != http.StatusGatewayTimeout {
+		t.Errorf("Middleware(timedOut) failed: got status %v, expected %v", rsp.Code, http.StatusGatewayTimeout)
+	}
+	if rsp.Body.String() != "too slow\n" {
+		t.Errorf("Middleware(timedOut) failed: got body %q, expected too slow", rsp.Body.String())
+	}
+	if err := <-lateWrite; err != http.ErrHandlerTimeout {
+		t.Errorf("Middleware(timedOut) failed: late write got %v, expected %v", err, http.ErrHandlerTimeout)
+	}
+}
+
+func Test_Middleware_Streaming(t *testing.T) {
+	flushed := ""
+	m := NewMiddleware(WithTimeout(time.Second))
+	var rsp *httptest.ResponseRecorder
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(interface{ Unwrap() http.ResponseWriter }); !ok {
+			t.Error("Middleware(streaming) failed: Unwrap not available")
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("Middleware(streaming) failed: http.Flusher not preserved")
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("data: 1\n\n"))
+		flusher.Flush()
+		flushed = rsp.Body.String()
+		w.Write([]byte("data: 2\n\n"))
+	}))
+
+	rsp = httptest.NewRecorder()
+	test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if flushed != "data: 1\n\n" {
+		t.Errorf("Middleware(streaming) failed: got flushed %q, expected first event", flushed)
+	}
+	if rsp.Code != http.StatusAccepted || rsp.Body.String() != "data: 1\n\ndata: 2\n\n" {
+		t.Errorf("Middleware(streaming) failed: got %v %q, expected %v with both events", rsp.Code, rsp.Body.String(), http.StatusAccepted)
+	}
+}
+
+func Test_Middleware_Interfaces(t *testing.T) {
+	m := NewMiddleware(WithTimeout(time.Second))
+	exposed := false
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, exposed = w.(http.Flusher)
+	}))
+
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !exposed {
+		t.Error("Middleware(interfaces) failed: flusher not exposed")
+	}
+	test.ServeHTTP(struct{ http.ResponseWriter }{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/", nil))
+	if exposed {
+		t.Error("Middleware(interfaces) failed: flusher exposed for a writer without it")
+	}
+}
+
+func Test_Middleware_StreamingTimedOut(t *testing.T) {
+	m := NewMiddleware(WithTimeout(10 * time.Millisecond))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: 1\n\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+
+	rsp := httptest.NewRecorder()
+	test.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rsp.Code != http.StatusOK || rsp.Body.String() != "data: 1\n\n" {
+		t.Errorf("Middleware(streamingTimedOut) failed: got %v %q, expected stream without timeout response", rsp.Code, rsp.Body.String())
+	}
+}
+
+func Test_Middleware_RouteTimeout(t *testing.T) {
+	r := router.NewRouter()
+	UseMiddleware(r, WithTimeout(10*time.Millisecond))
+	r.HandleFunc("/export", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("Middleware(disabled) failed: context deadline set")
+		}
+		w.WriteHeader(http.StatusOK)
+	}, Disabled())
+	r.HandleFunc("/lookup", func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := r.Context().Deadline()
+		if !ok || time.Until(deadline) < 500*time.Millisecond {
+			t.Error("Middleware(routeTimeout) failed: route timeout not applied")
+		}
+	}, Timeout(time.Second))
+
+	for _, path := range []string{"/export", "/lookup"} {
+		rsp := httptest.NewRecorder()
+		r.ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, path, nil))
+		if rsp.Code != http.StatusOK {
+			t.Errorf("Middleware(%s) failed: got status %v, expected %v", path, rsp.Code, http.StatusOK)
+		}
+	}
+}
+
+func Test_Middleware_Panic(t *testing.T) {
+	m := NewMiddleware(WithTimeout(time.Second))
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	defer func() {
+		if rec := recover(); rec != "boom" {
+			t.Errorf("Middleware(panic) failed: got %v, expected boom", rec)
+		}
+	}()
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,36 @@
+package timeout
+
+import (
+	"net/http"
+	"time"
+)
+
+func WithTimeout(d time.Duration) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Timeout = d
+	}
+}
+
+func WithStatusCode(statusCode int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.StatusCode = statusCode
+	}
+}
+
+func WithMessage(message string) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Message = message
+	}
+}
+
+func WithTimeoutHandler(handler http.Handler) MiddlewareOption {
+	return func(m *Middleware) {
+		m.TimeoutHandler = handler
+	}
+}
+
+func WithTimeoutHandlerFunc(handle func(http.ResponseWriter, *http.Request)) MiddlewareOption {
+	return func(m *Middleware) {
+		m.TimeoutHandler = http.HandlerFunc(handle)
+	}
+}
@@ -0,0 +1,58 @@
+package timeout
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_WithTimeout(t *testing.T) {
+	m := &Middleware{}
+	option := WithTimeout(time.Second)
+	option(m)
+
+	if m.Timeout != time.Second {
+		t.Errorf("WithTimeout() failed: got %v, expected %v", m.Timeout, time.Second)
+	}
+}
+
+func Test_WithStatusCode(t *testing.T) {
+	m := &Middleware{}
+	option := WithStatusCode(http.StatusGatewayTimeout)
+	option(m)
+
+	if m.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("WithStatusCode() failed: got %v, expected %v", m.StatusCode, http.StatusGatewayTimeout)
+	}
+}
+
+func Test_WithMessage(t *testing.T) {
+	expected := "test"
+	m := &Middleware{}
+	option := WithMessage(expected)
+	option(m)
+
+	if m.Message != expected {
+		t.Errorf("WithMessage() failed: got %s, expected %s", m.Message, expected)
+	}
+}
+
+func Test_WithTimeoutHandler(t *testing.T) {
+	m := &Middleware{}
+	option := WithTimeoutHandler(http.NotFoundHandler())
+	option(m)
+
+	if m.TimeoutHandler == nil {
+		t.Error("WithTimeoutHandler() failed: handler not set")
+	}
+}
+
+func Test_WithTimeoutHandlerFunc(t *testing.T) {
+	m := &Middleware{}
+	option := WithTimeoutHandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	option(m)
+
+	if m.TimeoutHandler == nil {
+		t.Error("WithTimeoutHandlerFunc() failed: handler not set")
+	}
+}
@@ -0,0 +1,178 @@
+package timeout
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::timeout"
+
+	DefaultStatusCode int = http.StatusServiceUnavailable
+)
+
+func Timeout(d time.Duration) router.RouteOption {
+	return func(r *router.Route) {
+		r.SetMetadata(metadataKey, d)
+	}
+}
+
+func Disabled() router.RouteOption {
+	return Timeout(0)
+}
+
+func GetRouteTimeout(route *router.Route) (time.Duration, bool) {
+	if route == nil {
+		return 0, false
+	}
+	value, ok := route.GetMetadata(metadataKey)
+	if !ok {
+		return 0, false
+	}
+	d, ok := value.(time.Duration)
+	return d, ok
+}
+
+type timeoutWriter struct {
+	ctx         context.Context
+	writer      http.ResponseWriter
+	mu          sync.Mutex
+	header      http.Header
+	buffer      bytes.Buffer
+	status      int
+	wroteHeader bool
+	timedOut    bool
+	streaming   bool
+	hijacked    bool
+}
+
+type timeoutFlusher struct{ w *timeoutWriter }
+type timeoutHijacker struct{ w *timeoutWriter }
+
+func newTimeoutWriter(ctx context.Context, w http.ResponseWriter) (*timeoutWriter, http.ResponseWriter) {
+	tw := &timeoutWriter{
+		ctx:    ctx,
+		writer: w,
+		header: make(http.Header),
+	}
+	_, isFlusher := w.(http.Flusher)
+	_, isHijacker := w.(http.Hijacker)
+	switch {
+	case isFlusher && isHijacker:
+		return tw, struct {
+			*timeoutWriter
+			timeoutFlusher
+			timeoutHijacker
+		}{tw, timeoutFlusher{tw}, timeoutHijacker{tw}}
+	case isFlusher:
+		return tw, struct {
+			*timeoutWriter
+			timeoutFlusher
+		}{tw, timeoutFlusher{tw}}
+	case isHijacker:
+		return tw, struct {
+			*timeoutWriter
+			timeoutHijacker
+		}{tw, timeoutHijacker{tw}}
+	}
+	return tw, tw
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut || w.ctx.Err() == context.DeadlineExceeded {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !w.wroteHeader {
+		w.writeHeaderLocked(http.StatusOK)
+	}
+	if w.streaming {
+		return w.writer.Write(b)
+	}
+	return w.buffer.Write(b)
+}
+
+func (w *timeoutWriter) WriteHeader(statusCode int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut || w.wroteHeader {
+		return
+	}
+	w.writeHeaderLocked(statusCode)
+}
+
+func (w *timeoutWriter) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
+func (w *timeoutWriter) writeHeaderLocked(statusCode int) {
+	w.wroteHeader = true
+	w.status = statusCode
+}
+
+func (w *timeoutWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.streaming || w.hijacked {
+		return
+	}
+	w.flushLocked()
+}
+
+func (w *timeoutWriter) flushLocked() {
+	header := w.writer.Header()
+	for key, values := range w.header {
+		header[key] = values
+	}
+	if !w.wroteHeader {
+		w.status = http.StatusOK
+	}
+	w.writer.WriteHeader(w.status)
+	w.writer.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+}
+
+func (w *timeoutWriter) timeout() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timedOut = true
+	return !w.streaming && !w.hijacked
+}
+
+func (f timeoutFlusher) Flush() {
+	f.w.mu.Lock()
+	defer f.w.mu.Unlock()
+	if f.w.timedOut || f.w.ctx.Err() == context.DeadlineExceeded {
+		return
+	}
+	if !f.w.streaming {
+		f.w.streaming = true
+		f.w.flushLocked()
+	}
+	f.w.writer.(http.Flusher).Flush()
+}
+
+func (h timeoutHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.w.mu.Lock()
+	defer h.w.mu.Unlock()
+	if h.w.timedOut {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+	conn, rw, err := h.w.writer.(http.Hijacker).Hijack()
+	if err == nil {
+		h.w.hijacked = true
+	}
+	return conn, rw, err
+}
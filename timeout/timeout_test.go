@@ -0,0 +1,39 @@
+package timeout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_Timeout(t *testing.T) {
+	route := &router.Route{}
+	option := Timeout(time.Minute)
+	option(route)
+
+	result, ok := GetRouteTimeout(route)
+	if !ok || result != time.Minute {
+		t.Errorf("Timeout() failed: got %v, expected %v", result, time.Minute)
+	}
+}
+
+func Test_Disabled(t *testing.T) {
+	route := &router.Route{}
+	option := Disabled()
+	option(route)
+
+	result, ok := GetRouteTimeout(route)
+	if !ok || result != 0 {
+		t.Errorf("Disabled() failed: got %v, expected 0", result)
+	}
+}
+
+func Test_GetRouteTimeout_NotSet(t *testing.T) {
+	if _, ok := GetRouteTimeout(nil); ok {
+		t.Error("GetRouteTimeout(nil) failed: got true, expected false")
+	}
+	if _, ok := GetRouteTimeout(&router.Route{}); ok {
+		t.Error("GetRouteTimeout(route) failed: got true, expected false")
+	}
+}
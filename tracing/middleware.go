@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/authentication"
+	"github.com/deb-ict/go-router/authorization"
+)
+
+const (
+	AttributeHttpMethod          string = "http.request.method"
+	AttributeHttpRoute           string = "http.route"
+	AttributeHttpStatusCode      string = "http.response.status_code"
+	AttributeUrlPath             string = "url.path"
+	AttributeRequestId           string = "http.request.id"
+	AttributeEndUserId           string = "enduser.id"
+	AttributeAuthorizationPolicy string = "authorization.policy"
+	AttributeAuthorizationResult string = "authorization.result"
+)
+
+type MiddlewareOption func(*Middleware)
+
+type Middleware struct {
+	Tracer Tracer
+}
+
+func NewMiddleware(opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.EnsureDefaults()
+
+	return m
+}
+
+func UseMiddleware(router *router.Router, opts ...MiddlewareOption) {
+	m := NewMiddleware(opts...)
+	router.Use(m.Middleware)
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := router.CurrentRoute(r)
+		template := ""
+		if route != nil {
+			template = route.GetTemplate()
+		}
+
+		parent, _ := Extract(r.Header)
+		ctx, span := m.Tracer.Start(r.Context(), r.Method+" "+template, parent)
+		ctx = ContextWithSpan(ctx, span)
+		ctx, result := authorization.WithResult(ctx)
+		defer span.End()
+
+		span.SetAttribute(AttributeHttpMethod, r.Method)
+		span.SetAttribute(AttributeHttpRoute, template)
+		span.SetAttribute(AttributeUrlPath, r.URL.Path)
+		if requestId := router.RequestId(r); requestId != "" {
+			span.SetAttribute(AttributeRequestId, requestId)
+		}
+		auth := authentication.GetContext(r.Context())
+		if auth.IsAuthenticated() {
+			span.SetAttribute(AttributeEndUserId, auth.GetSubjectId())
+		}
+
+		rw := router.NewResponseWriter(w)
+		defer func() {
+			if rec := recover(); rec != nil {
+				span.SetAttribute(AttributeHttpStatusCode, http.StatusInternalServerError)
+				span.SetStatus(StatusError, "panic")
+				panic(rec)
+			}
+		}()
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		status := rw.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetAttribute(AttributeHttpStatusCode, status)
+		if result.Subject != "" {
+			span.SetAttribute(AttributeEndUserId, result.Subject)
+		}
+		if result.Outcome != "" {
+			span.SetAttribute(AttributeAuthorizationPolicy, result.Policy)
+			span.SetAttribute(AttributeAuthorizationResult, result.Outcome)
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(StatusError, http.StatusText(status))
+		}
+	})
+}
+
+func (m *Middleware) EnsureDefaults() {
+	if m.Tracer == nil {
+		m.Tracer = NewNoopTracer()
+	}
+}
@@ -0,0 +1,195 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+	"github.com/deb-ict/go-router/authentication"
+	"github.com/deb-ict/go-router/authorization"
+)
+
+type recordingSpan struct {
+	name        string
+	parent      SpanContext
+	spanContext SpanContext
+	attributes  map[string]any
+	status      StatusCode
+	ended       bool
+}
+
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, parent SpanContext) (context.Context, Span) {
+	_, noop := NewNoopTracer().Start(ctx, name, parent)
+	span := &recordingSpan{
+		name:        name,
+		parent:      parent,
+		spanContext: noop.SpanContext(),
+		attributes:  make(map[string]any),
+	}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func (s *recordingSpan) SpanContext() SpanContext {
+	return s.spanContext
+}
+
+func (s *recordingSpan) SetAttribute(key string, value any) {
+	s.attributes[key] = value
+}
+
+func (s *recordingSpan) SetStatus(code StatusCode, description string) {
+	s.status = code
+}
+
+func (s *recordingSpan) End() {
+	s.ended = true
+}
+
+func MiddlewareOptionMock(called *int) MiddlewareOption {
+	return func(m *Middleware) {
+		*called++
+	}
+}
+
+func Test_NewMiddleware(t *testing.T) {
+	optionCalled := 0
+	o := MiddlewareOptionMock(&optionCalled)
+	m := NewMiddleware(o)
+
+	if m == nil {
+		t.Error("NewMiddleware() failed: No instance")
+	} else {
+		if optionCalled != 1 {
+			t.Error("NewMiddleware() failed: Options not applied")
+		}
+		if m.Tracer == nil {
+			t.Error("NewMiddleware() failed: Default tracer not set")
+		}
+	}
+}
+
+func Test_UseMiddleware(t *testing.T) {
+	router := &router.Router{}
+	UseMiddleware(router)
+
+	if len(router.Middlewares()) != 1 {
+		t.Error("UseMiddleware() failed: Middleware not set on router")
+	}
+}
+
+func Test_Middleware(t *testing.T) {
+	tracer := &recordingTracer{}
+	outgoing := http.Header{}
+
+	r := router.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := make(authentication.ClaimMap)
+			claims.AddClaim(authentication.ClaimSubjectId, "user-1")
+			claims.SetName("user")
+			ctx := authentication.SetContext(r.Context(), authentication.NewContext(true, claims))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	UseMiddleware(r, WithTracer(tracer))
+	authorization.UseMiddleware(r, authorization.WithPolicy("admin", authorization.NewRoleRequirement("admin")))
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		Inject(r.Context(), outgoing)
+	}).Authorize("admin")
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set(HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("Middleware() failed: got %v spans, expected 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "GET /items/{id}" {
+		t.Errorf("Middleware() failed: got span name %v, expected GET /items/{id}", span.name)
+	}
+	if span.parent.TraceId.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Middleware() failed: parent not extracted: got %v", span.parent.TraceId)
+	}
+	expected := map[string]any{
+		AttributeHttpMethod:          http.MethodGet,
+		AttributeHttpRoute:           "/items/{id}",
+		AttributeHttpStatusCode:      http.StatusForbidden,
+		AttributeEndUserId:           "user-1",
+		AttributeAuthorizationPolicy: "admin",
+		AttributeAuthorizationResult: authorization.OutcomeForbidden,
+	}
+	for key, value := range expected {
+		if span.attributes[key] != value {
+			t.Errorf("Middleware() failed: attribute %s: got %v, expected %v", key, span.attributes[key], value)
+		}
+	}
+	if !span.ended {
+		t.Error("Middleware() failed: span not ended")
+	}
+	if len(outgoing) != 0 {
+		t.Error("Middleware() failed: handler executed for forbidden request")
+	}
+}
+
+func Test_Middleware_BeforeAuthentication(t *testing.T) {
+	tracer := &recordingTracer{}
+
+	r := router.NewRouter()
+	UseMiddleware(r, WithTracer(tracer))
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := make(authentication.ClaimMap)
+			claims.AddClaim(authentication.ClaimSubjectId, "user-1")
+			claims.AddClaim(authentication.ClaimRole, "admin")
+			ctx := authentication.SetContext(r.Context(), authentication.NewContext(true, claims))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	authorization.UseMiddleware(r, authorization.WithPolicy("admin", authorization.NewRoleRequirement("admin")))
+	r.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}).Authorize("admin")
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/1", nil))
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("Middleware() failed: got %v spans, expected 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	expected := map[string]any{
+		AttributeHttpStatusCode:      http.StatusForbidden,
+		AttributeEndUserId:           "user-1",
+		AttributeAuthorizationResult: authorization.OutcomeAllowed,
+	}
+	for key, value := range expected {
+		if span.attributes[key] != value {
+			t.Errorf("Middleware() failed: attribute %s: got %v, expected %v", key, span.attributes[key], value)
+		}
+	}
+}
+
+func Test_Middleware_Propagation(t *testing.T) {
+	outgoing := http.Header{}
+	m := NewMiddleware()
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Inject(r.Context(), outgoing)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	test.ServeHTTP(httptest.NewRecorder(), req)
+
+	sc, ok := ParseTraceParent(outgoing.Get(HeaderTraceParent))
+	if !ok || sc.TraceId.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Middleware(propagation) failed: got traceparent %v", outgoing.Get(HeaderTraceParent))
+	}
+}
@@ -0,0 +1,7 @@
+package tracing
+
+func WithTracer(tracer Tracer) MiddlewareOption {
+	return func(m *Middleware) {
+		m.Tracer = tracer
+	}
+}
@@ -0,0 +1,16 @@
+package tracing
+
+import (
+	"testing"
+)
+
+func Test_WithTracer(t *testing.T) {
+	tracer := NewNoopTracer()
+	m := &Middleware{}
+	option := WithTracer(tracer)
+	option(m)
+
+	if m.Tracer != tracer {
+		t.Error("WithTracer() failed: tracer not set")
+	}
+}
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+)
+
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = iota
+	StatusOk
+	StatusError
+)
+
+type Tracer interface {
+	Start(ctx context.Context, name string, parent SpanContext) (context.Context, Span)
+}
+
+type Span interface {
+	SpanContext() SpanContext
+	SetAttribute(key string, value any)
+	SetStatus(code StatusCode, description string)
+	End()
+}
+
+type noopTracer struct {
+}
+
+type noopSpan struct {
+	spanContext SpanContext
+}
+
+func NewNoopTracer() Tracer {
+	return &noopTracer{}
+}
+
+func (t *noopTracer) Start(ctx context.Context, name string, parent SpanContext) (context.Context, Span) {
+	sc := SpanContext{
+		TraceId:    parent.TraceId,
+		SpanId:     NewSpanId(),
+		Flags:      parent.Flags,
+		TraceState: parent.TraceState,
+	}
+	if !parent.IsValid() {
+		sc.TraceId = NewTraceId()
+		sc.Flags = FlagSampled
+	}
+	return ctx, &noopSpan{spanContext: sc}
+}
+
+func (s *noopSpan) SpanContext() SpanContext {
+	return s.spanContext
+}
+
+func (s *noopSpan) SetAttribute(key string, value any) {
+}
+
+func (s *noopSpan) SetStatus(code StatusCode, description string) {
+}
+
+func (s *noopSpan) End() {
+}
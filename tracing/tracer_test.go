@@ -0,0 +1,23 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_NoopTracer_Start(t *testing.T) {
+	tracer := NewNoopTracer()
+
+	_, root := tracer.Start(context.Background(), "root", SpanContext{})
+	if !root.SpanContext().IsValid() {
+		t.Error("NoopTracer.Start(root) failed: invalid span context")
+	}
+
+	_, child := tracer.Start(context.Background(), "child", root.SpanContext())
+	if child.SpanContext().TraceId != root.SpanContext().TraceId {
+		t.Error("NoopTracer.Start(child) failed: trace id not inherited")
+	}
+	if child.SpanContext().SpanId == root.SpanContext().SpanId {
+		t.Error("NoopTracer.Start(child) failed: span id not regenerated")
+	}
+}
@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	spanKey router.ContextKey = "router::tracing::span"
+
+	HeaderTraceParent string = "traceparent"
+	HeaderTraceState  string = "tracestate"
+
+	FlagSampled byte = 0x01
+)
+
+type TraceId [16]byte
+type SpanId [8]byte
+
+type SpanContext struct {
+	TraceId    TraceId
+	SpanId     SpanId
+	Flags      byte
+	TraceState string
+	Remote     bool
+}
+
+func NewTraceId() TraceId {
+	var id TraceId
+	for id.IsZero() {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+func NewSpanId() SpanId {
+	var id SpanId
+	for id.IsZero() {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+func (id TraceId) IsZero() bool {
+	return id == TraceId{}
+}
+
+func (id TraceId) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id SpanId) IsZero() bool {
+	return id == SpanId{}
+}
+
+func (id SpanId) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (sc SpanContext) IsValid() bool {
+	return !sc.TraceId.IsZero() && !sc.SpanId.IsZero()
+}
+
+func (sc SpanContext) IsSampled() bool {
+	return sc.Flags&FlagSampled != 0
+}
+
+func (sc SpanContext) TraceParent() string {
+	return "00-" + sc.TraceId.String() + "-" + sc.SpanId.String() + "-" + hex.EncodeToString([]byte{sc.Flags})
+}
+
+func ParseTraceParent(value string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, false
+	}
+	version, ok := decodeHex(parts[0], 1)
+	if !ok || version[0] == 0xff || (version[0] == 0 && len(parts) != 4) {
+		return SpanContext{}, false
+	}
+	traceId, ok := decodeHex(parts[1], 16)
+	if !ok {
+		return SpanContext{}, false
+	}
+	spanId, ok := decodeHex(parts[2], 8)
+	if !ok {
+		return SpanContext{}, false
+	}
+	flags, ok := decodeHex(parts[3], 1)
+	if !ok {
+		return SpanContext{}, false
+	}
+
+	sc := SpanContext{
+		Flags:  flags[0],
+		Remote: true,
+	}
+	copy(sc.TraceId[:], traceId)
+	copy(sc.SpanId[:], spanId)
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+func Extract(header http.Header) (SpanContext, bool) {
+	sc, ok := ParseTraceParent(header.Get(HeaderTraceParent))
+	if !ok {
+		return SpanContext{}, false
+	}
+	sc.TraceState = strings.Join(header.Values(HeaderTraceState), ",")
+	return sc, true
+}
+
+func Inject(ctx context.Context, header http.Header) {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return
+	}
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return
+	}
+	header.Set(HeaderTraceParent, sc.TraceParent())
+	if sc.TraceState != "" {
+		header.Set(HeaderTraceState, sc.TraceState)
+	} else {
+		header.Del(HeaderTraceState)
+	}
+}
+
+func SpanFromContext(ctx context.Context) Span {
+	value := ctx.Value(spanKey)
+	if value == nil {
+		return nil
+	}
+	return value.(Span)
+}
+
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey, span)
+}
+
+func decodeHex(value string, size int) ([]byte, bool) {
+	if len(value) != size*2 || strings.ToLower(value) != value {
+		return nil, false
+	}
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
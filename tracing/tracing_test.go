@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func Test_ParseTraceParent(t *testing.T) {
+	type testCase struct {
+		value    string
+		expected bool
+	}
+	tests := []testCase{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false},
+		{"00-4bf92f3577b34da6-00f067aa0ba902b7-01", false},
+		{"", false},
+	}
+
+	for _, tc := range tests {
+		sc, ok := ParseTraceParent(tc.value)
+		if ok != tc.expected {
+			t.Errorf("ParseTraceParent(%s) failed: got %v, expected %v", tc.value, ok, tc.expected)
+		}
+		if ok && !sc.Remote {
+			t.Errorf("ParseTraceParent(%s) failed: span context not marked as remote", tc.value)
+		}
+	}
+}
+
+func Test_SpanContext_TraceParent(t *testing.T) {
+	value := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, _ := ParseTraceParent(value)
+
+	if result := sc.TraceParent(); result != value {
+		t.Errorf("SpanContext.TraceParent() failed: got %v, expected %v", result, value)
+	}
+	if !sc.IsSampled() {
+		t.Error("SpanContext.IsSampled() failed: got false, expected true")
+	}
+}
+
+func Test_Extract(t *testing.T) {
+	header := http.Header{}
+	header.Set(HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	header.Add(HeaderTraceState, "vendor1=a")
+	header.Add(HeaderTraceState, "vendor2=b")
+
+	sc, ok := Extract(header)
+	if !ok {
+		t.Fatal("Extract() failed: traceparent not parsed")
+	}
+	if sc.TraceState != "vendor1=a,vendor2=b" {
+		t.Errorf("Extract() failed: got tracestate %v, expected vendor1=a,vendor2=b", sc.TraceState)
+	}
+}
+
+func Test_Inject(t *testing.T) {
+	header := http.Header{}
+	Inject(context.Background(), header)
+	if header.Get(HeaderTraceParent) != "" {
+		t.Error("Inject(noSpan) failed: traceparent set")
+	}
+
+	parent, _ := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	parent.TraceState = "vendor=a"
+	_, span := NewNoopTracer().Start(context.Background(), "test", parent)
+	ctx := ContextWithSpan(context.Background(), span)
+	Inject(ctx, header)
+
+	sc, ok := ParseTraceParent(header.Get(HeaderTraceParent))
+	if !ok || sc.TraceId != parent.TraceId || sc.SpanId == parent.SpanId {
+		t.Errorf("Inject() failed: got traceparent %v", header.Get(HeaderTraceParent))
+	}
+	if header.Get(HeaderTraceState) != "vendor=a" {
+		t.Errorf("Inject() failed: got tracestate %v, expected vendor=a", header.Get(HeaderTraceState))
+	}
+}
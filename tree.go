@@ -1,22 +1,35 @@
 package router
 
 import (
+	"net/http"
 	"slices"
 	"strings"
 )
 
 type NodeFilter func(n *Node)
 
+var knownMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
 type NodeType int
 
 const (
 	NodeTypeUndefined NodeType = iota
 	NodeTypePath
 	NodeTypeParam
+	NodeTypeWildcard
 )
 
 type Node struct {
 	Segment string
+	Name    string
 	Type    NodeType
 	Parent  *Node
 	Nodes   []*Node
@@ -51,6 +64,57 @@ func (n *Node) FindNode(pattern string, params RouteParams) *Node {
 	return n.findSegment(segments, params)
 }
 
+func (n *Node) Walk(filter NodeFilter) {
+	filter(n)
+	for _, node := range n.Nodes {
+		node.Walk(filter)
+	}
+}
+
+func (n *Node) GetAllowedMethods() []string {
+	candidates := slices.Clone(knownMethods)
+	for _, route := range n.Routes {
+		for _, method := range route.methods {
+			if !slices.Contains(candidates, method) {
+				candidates = append(candidates, method)
+			}
+		}
+	}
+
+	methods := make([]string, 0)
+	for _, method := range candidates {
+		if method == http.MethodOptions {
+			continue
+		}
+		for _, route := range n.Routes {
+			if route.handler != nil && route.IsMethodAllowed(method) {
+				methods = append(methods, method)
+				break
+			}
+		}
+	}
+	if len(methods) > 0 {
+		methods = append(methods, http.MethodOptions)
+	}
+	return methods
+}
+
+func (n *Node) GetTemplate() string {
+	segments := make([]string, 0)
+	for node := n; node != nil && node.Parent != nil; node = node.Parent {
+		segment := node.Segment
+		switch node.Type {
+		case NodeTypeParam:
+			segment = "{" + segment + "}"
+		case NodeTypeWildcard:
+			segment = "{" + segment + "...}"
+		}
+		segments = append(segments, segment)
+	}
+	slices.Reverse(segments)
+	return "/" + strings.Join(segments, "/")
+}
+
 func (n *Node) getPath(pattern string) string {
 	i := strings.Index(pattern, "?")
 	if i == -1 {
@@ -88,6 +152,7 @@ func (n *Node) buildSegment(segments []string) *Node {
 	if node == nil /*|| (node.hasHandler() && numSegments == 1)*/ {
 		node = &Node{
 			Segment: nodeValue,
+			Name:    n.getNodeValue(segments[0], nodeType),
 			Type:    nodeType,
 			Parent:  n,
 		}
@@ -105,17 +170,16 @@ func (n *Node) findSegment(segments []string, params RouteParams) *Node {
 	// Validate the segments
 	numSegments := len(segments)
 	if numSegments == 0 {
-		return n
+		return n.matchEmptyWildcard(params)
 	}
 	if segments[0] == "" && numSegments == 1 {
-		return n
+		return n.matchEmptyWildcard(params)
 	}
 	if segments[0] == "" && numSegments > 1 {
 		return n.findSegment(segments[1:], params)
 	}
 
-	segment := strings.ToLower(segments[0])
-	return n.findChildSegment(segment, segments, params)
+	return n.findChildSegment(segments[0], segments, params)
 	/*
 		if child != nil {
 			return child.findSegment(segments[1:], params)
@@ -125,36 +189,101 @@ func (n *Node) findSegment(segments []string, params RouteParams) *Node {
 }
 
 func (n *Node) findChildSegment(segment string, segments []string, params RouteParams) *Node {
-	if n.Nodes != nil {
-		for _, node := range n.Nodes {
-			if node.Type == NodeTypeParam {
-				if len(segments) > 1 {
-					next := node.findChildSegment(segments[1], segments[1:], params)
-					if next != nil {
-						params[node.Segment] = segment
-						return next
-					}
-				} else {
-					params[node.Segment] = segment
-					return node
-				}
-			}
-			if node.Type == NodeTypePath && node.Segment == segment {
-				if len(segments) > 1 {
-					next := node.findChildSegment(segments[1], segments[1:], params)
-					if next != nil {
-						return next
-					}
-				} else {
-					return node
-				}
+	if next := n.matchChildSegment(segment, segments, params); next != nil {
+		return next
+	}
+	for _, node := range n.Nodes {
+		if node.Type == NodeTypeWildcard {
+			params[node.Segment] = strings.Join(segments, "/")
+			return node
+		}
+	}
+	return nil
+}
+
+func (n *Node) matchEmptyWildcard(params RouteParams) *Node {
+	if n.hasHandler() {
+		return n
+	}
+	for _, node := range n.Nodes {
+		if node.Type == NodeTypeWildcard {
+			params[node.Segment] = ""
+			return node
+		}
+	}
+	return n
+}
+
+func (n *Node) matchParentWildcard(node *Node, pattern string, params RouteParams) *Node {
+	segments := strings.Split(n.getPath(pattern), "/")
+	segments = slices.DeleteFunc(segments, func(s string) bool {
+		return s == ""
+	})
+
+	depth := 0
+	for current := node; current != nil && current != n; current = current.Parent {
+		depth++
+	}
+	for current := node.Parent; current != nil && depth > 0; current = current.Parent {
+		depth--
+		for _, child := range current.Nodes {
+			if child.Type == NodeTypeWildcard && child.hasHandler() {
+				params[child.Segment] = strings.Join(segments[depth:], "/")
+				return child
 			}
 		}
+		if current == n {
+			break
+		}
+	}
+	return nil
+}
+
+func (n *Node) hasHandler() bool {
+	for _, route := range n.Routes {
+		if route.handler != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) matchChildSegment(segment string, segments []string, params RouteParams) *Node {
+	// Path segments match case-insensitively, param values keep the case of the request
+	key := strings.ToLower(segment)
+
+	// Static segments take precedence over params, wildcards are tried last by findChildSegment
+	for _, node := range n.Nodes {
+		if node.Type != NodeTypePath || node.Segment != key {
+			continue
+		}
+		if len(segments) == 1 {
+			return node.matchEmptyWildcard(params)
+		}
+		if next := node.findChildSegment(segments[1], segments[1:], params); next != nil {
+			return next
+		}
+	}
+	for _, node := range n.Nodes {
+		if node.Type != NodeTypeParam {
+			continue
+		}
+		if len(segments) == 1 {
+			params[node.Segment] = segment
+			return node.matchEmptyWildcard(params)
+		}
+		if next := node.findChildSegment(segments[1], segments[1:], params); next != nil {
+			params[node.Segment] = segment
+			return next
+		}
 	}
 	return nil
 }
 
 func (n *Node) getNodeType(segment string) NodeType {
+	if n.isWildcardSegment(segment) {
+		return NodeTypeWildcard
+	}
 	if n.isParamSegment(segment) {
 		return NodeTypeParam
 	}
@@ -162,6 +291,9 @@ func (n *Node) getNodeType(segment string) NodeType {
 }
 
 func (n *Node) getNodeValue(segment string, nodeType NodeType) string {
+	if nodeType == NodeTypeWildcard {
+		return segment[1 : len(segment)-4]
+	}
 	if nodeType == NodeTypeParam {
 		return segment[1 : len(segment)-1]
 	}
@@ -172,8 +304,14 @@ func (n *Node) isParamSegment(segment string) bool {
 	return segment != "" && segment[0] == '{' && segment[len(segment)-1] == '}'
 }
 
+func (n *Node) isWildcardSegment(segment string) bool {
+	return n.isParamSegment(segment) && strings.HasSuffix(segment, "...}") && len(segment) >= 5
+}
+
 func (t NodeType) String() string {
 	switch t {
+	case NodeTypeWildcard:
+		return "wildcard"
 	case NodeTypePath:
 		return "path"
 	case NodeTypeParam:
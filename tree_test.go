@@ -2,6 +2,7 @@ package router
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -99,6 +100,122 @@ func Test_Node_FindNode(t *testing.T) {
 	}
 }
 
+func Test_Node_GetTemplate(t *testing.T) {
+	type testCase struct {
+		pattern  string
+		expected string
+	}
+	tests := []testCase{
+		{"/", "/"},
+		{"/api", "/api"},
+		{"/api/v1/", "/api/v1"},
+		{"/api/{id}/items", "/api/{id}/items"},
+		{"/API/{Id}", "/api/{id}"},
+	}
+
+	for _, tc := range tests {
+		root := &Node{}
+		node := root.BuildTree(tc.pattern)
+		result := node.GetTemplate()
+		if result != tc.expected {
+			t.Errorf("Node.GetTemplate(%s) failed: got %v, expected %v", tc.pattern, result, tc.expected)
+		}
+	}
+}
+
+func Test_Node_FindNode_Wildcard(t *testing.T) {
+	type testCase struct {
+		pattern  string
+		expected string
+		param    string
+	}
+	tests := []testCase{
+		{"/static/css/Site.css", "/static/{filepath...}", "css/Site.css"},
+		{"/static/app.js", "/static/{filepath...}", "app.js"},
+		{"/static", "/static", ""},
+		{"/api/items", "/api/items", ""},
+		{"/api/unknown", "/{filepath...}", "api/unknown"},
+		{"/dashboard/settings", "/{filepath...}", "dashboard/settings"},
+	}
+
+	root := &Node{}
+	for _, pattern := range []string{"/static", "/static/{filepath...}", "/api/items", "/{filepath...}"} {
+		root.BuildTree(pattern).Routes = []*Route{{handler: http.NotFoundHandler()}}
+	}
+	for _, tc := range tests {
+		params := make(RouteParams)
+		node := root.FindNode(tc.pattern, params)
+		if node == nil {
+			t.Errorf("Node.FindNode(%s) failed: got <nil>, expected %s", tc.pattern, tc.expected)
+			continue
+		}
+		if result := node.GetTemplate(); result != tc.expected {
+			t.Errorf("Node.FindNode(%s) failed: got %s, expected %s", tc.pattern, result, tc.expected)
+		}
+		if params["filepath"] != tc.param {
+			t.Errorf("Node.FindNode(%s) failed: got param %s, expected %s", tc.pattern, params["filepath"], tc.param)
+		}
+	}
+}
+
+func Test_Node_matchParentWildcard(t *testing.T) {
+	type testCase struct {
+		pattern  string
+		expected string
+		param    string
+	}
+	tests := []testCase{
+		{"/api", "/{filepath...}", "api"},
+		{"/api/v1", "/{filepath...}", "api/v1"},
+		{"/docs/api", "/docs/{filepath...}", "api"},
+	}
+
+	root := &Node{}
+	for _, pattern := range []string{"/api/v1/items", "/docs/api/index", "/docs/{filepath...}", "/{filepath...}"} {
+		root.BuildTree(pattern).Routes = []*Route{{handler: http.NotFoundHandler()}}
+	}
+	for _, tc := range tests {
+		params := make(RouteParams)
+		node := root.matchParentWildcard(root.FindNode(tc.pattern, params), tc.pattern, params)
+		if node == nil {
+			t.Errorf("Node.matchParentWildcard(%s) failed: got <nil>, expected %s", tc.pattern, tc.expected)
+			continue
+		}
+		if result := node.GetTemplate(); result != tc.expected {
+			t.Errorf("Node.matchParentWildcard(%s) failed: got %s, expected %s", tc.pattern, result, tc.expected)
+		}
+		if params["filepath"] != tc.param {
+			t.Errorf("Node.matchParentWildcard(%s) failed: got param %s, expected %s", tc.pattern, params["filepath"], tc.param)
+		}
+	}
+}
+
+func Test_Node_GetAllowedMethods(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	type testCase struct {
+		routes   []*Route
+		expected []string
+	}
+	tests := []testCase{
+		{nil, []string{}},
+		{[]*Route{{methods: []string{http.MethodGet}}}, []string{}},
+		{[]*Route{{methods: []string{http.MethodGet}, handler: handler}}, []string{http.MethodGet, http.MethodOptions}},
+		{[]*Route{
+			{methods: []string{http.MethodPost}, handler: handler},
+			{methods: []string{http.MethodGet, "PURGE"}, handler: handler},
+		}, []string{http.MethodGet, http.MethodPost, "PURGE", http.MethodOptions}},
+		{[]*Route{{handler: handler}}, []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}},
+	}
+
+	for _, tc := range tests {
+		node := &Node{Routes: tc.routes}
+		result := node.GetAllowedMethods()
+		if !slices.Equal(result, tc.expected) {
+			t.Errorf("Node.GetAllowedMethods() failed: got %v, expected %v", result, tc.expected)
+		}
+	}
+}
+
 func Test_Node_getPath(t *testing.T) {
 	type testCase struct {
 		pattern  string
@@ -220,7 +337,11 @@ func Test_Node_getNodeType(t *testing.T) {
 		segment  string
 		expected NodeType
 	}
-	tests := []testCase{}
+	tests := []testCase{
+		{"api", NodeTypePath},
+		{"{id}", NodeTypeParam},
+		{"{path...}", NodeTypeWildcard},
+	}
 
 	for _, tc := range tests {
 		node := &Node{}
@@ -240,6 +361,7 @@ func Test_Node_getNodeValue(t *testing.T) {
 	tests := []testCase{
 		{"api", NodeTypePath, "api"},
 		{"{id}", NodeTypeParam, "id"},
+		{"{path...}", NodeTypeWildcard, "path"},
 	}
 
 	for _, tc := range tests {
@@ -251,6 +373,28 @@ func Test_Node_getNodeValue(t *testing.T) {
 	}
 }
 
+func Test_Node_isWildcardSegment(t *testing.T) {
+	type testCase struct {
+		segment  string
+		expected bool
+	}
+	tests := []testCase{
+		{"{path...}", true},
+		{"{path}", false},
+		{"{...}", true},
+		{"{..}", false},
+		{"path...", false},
+	}
+
+	for _, tc := range tests {
+		node := &Node{}
+		result := node.isWildcardSegment(tc.segment)
+		if result != tc.expected {
+			t.Errorf("Node.isWildcardSegment(%s) failed: got %v, expected %v", tc.segment, result, tc.expected)
+		}
+	}
+}
+
 func Test_Node_isParamSegment(t *testing.T) {
 	type testCase struct {
 		segment  string
@@ -282,6 +426,11 @@ func Test_NodeType_String(t *testing.T) {
 		t.Errorf("NodeTypeParam.String() failed: got '%v', expected 'param'", param)
 	}
 
+	wildcard := NodeTypeWildcard.String()
+	if wildcard != "wildcard" {
+		t.Errorf("NodeTypeWildcard.String() failed: got '%v', expected 'wildcard'", wildcard)
+	}
+
 	undefined := NodeTypeUndefined.String()
 	if undefined != "undefined" {
 		t.Errorf("NodeTypeUndefined.String() failed: got '%v', expected 'undefined'", undefined)
@@ -348,3 +497,18 @@ func nodeName(n *Node) string {
 	}
 	return n.Segment
 }
+
+func Test_Node_Walk(t *testing.T) {
+	root := &Node{}
+	root.BuildTree("/api/users/{id}")
+	root.BuildTree("/api/orders")
+
+	segments := make([]string, 0)
+	root.Walk(func(n *Node) {
+		segments = append(segments, n.Segment)
+	})
+	expected := []string{"", "api", "users", "id", "orders"}
+	if !slices.Equal(segments, expected) {
+		t.Errorf("Node.Walk() failed: got %v, expected %v", segments, expected)
+	}
+}
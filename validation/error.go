@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"github.com/deb-ict/go-router"
+)
+
+type Error struct {
+	Context Context
+}
+
+func NewError(ctx Context) *Error {
+	return &Error{
+		Context: ctx,
+	}
+}
+
+func (e *Error) Error() string {
+	if message := e.Context.GetMessage(); message != "" {
+		return message
+	}
+	return "validation failed"
+}
+
+func (e *Error) Problem() *router.Problem {
+	return NewProblem(e.Context)
+}
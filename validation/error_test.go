@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_Error(t *testing.T) {
+	ctx := NewContext()
+	ctx.AddError("name", "required")
+
+	var err error = NewError(ctx)
+	if err.Error() != "validation failed" {
+		t.Errorf("Error.Error() failed: got %s, expected %s", err.Error(), "validation failed")
+	}
+	ctx.SetMessage("The order is invalid")
+	if err.Error() != "The order is invalid" {
+		t.Errorf("Error.Error() failed: got %s, expected %s", err.Error(), "The order is invalid")
+	}
+
+	var problemErr router.ProblemError
+	if !errors.As(err, &problemErr) {
+		t.Fatal("Error failed: does not implement router.ProblemError")
+	}
+	if problem := problemErr.Problem(); problem.Status != http.StatusBadRequest || problem.Extensions[ProblemErrorsMember] == nil {
+		t.Errorf("Error.Problem() failed: got %+v", problem)
+	}
+}
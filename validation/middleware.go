@@ -21,9 +21,7 @@ func NewMiddleware(opts ...MiddlewareOption) *Middleware {
 
 func (m *Middleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var validation Context
-
-		ctx := SetContext(r.Context(), validation)
+		ctx := SetContext(r.Context(), NewContext())
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
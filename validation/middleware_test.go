@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_Middleware(t *testing.T) {
+	var ctx Context
+	m := NewMiddleware()
+	test := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = GetContext(r.Context())
+		ctx.AddError("name", "required")
+		if GetContext(r.Context()) != ctx {
+			t.Error("Middleware() failed: context not shared within the request")
+		}
+	}))
+	test.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if ctx == nil || !ctx.HasErrors() {
+		t.Error("Middleware() failed: validation context not set")
+	}
+}
@@ -0,0 +1,19 @@
+package validation
+
+import (
+	"net/http"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	ProblemErrorsMember string = "errors"
+)
+
+func NewProblem(ctx Context) *router.Problem {
+	problem := router.NewProblem(http.StatusBadRequest, ctx.GetMessage())
+	if ctx.HasErrors() {
+		problem.With(ProblemErrorsMember, ctx.GetErrors())
+	}
+	return problem
+}
@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_NewProblem(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetMessage("The request is invalid")
+	ctx.AddError("name", "required")
+
+	problem := NewProblem(ctx)
+	if problem.Status != http.StatusBadRequest {
+		t.Errorf("NewProblem() failed: got status %v, expected %v", problem.Status, http.StatusBadRequest)
+	}
+	if problem.Detail != "The request is invalid" {
+		t.Errorf("NewProblem() failed: got detail %s, expected %s", problem.Detail, "The request is invalid")
+	}
+	errors, ok := problem.Extensions[ProblemErrorsMember].(ErrorMap)
+	if !ok || len(errors["name"]) != 1 {
+		t.Errorf("NewProblem() failed: got errors %v, expected name error", problem.Extensions[ProblemErrorsMember])
+	}
+}
+
+func Test_NewProblem_NoErrors(t *testing.T) {
+	problem := NewProblem(NewContext())
+	if _, ok := problem.Extensions[ProblemErrorsMember]; ok {
+		t.Error("NewProblem() failed: errors member set without errors")
+	}
+}
@@ -0,0 +1,14 @@
+package validation
+
+type Validatable interface {
+	Validate(ctx Context)
+}
+
+func Validate(v any, ctx Context) bool {
+	validatable, ok := v.(Validatable)
+	if !ok {
+		return true
+	}
+	validatable.Validate(ctx)
+	return !ctx.HasErrors()
+}
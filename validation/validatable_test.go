@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"testing"
+)
+
+type validatableMock struct {
+	Name string
+}
+
+func (v *validatableMock) Validate(ctx Context) {
+	if v.Name == "" {
+		ctx.AddError("name", "required")
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected bool
+	}{
+		{&validatableMock{Name: "test"}, true},
+		{&validatableMock{}, false},
+		{validatableMock{}, true},
+		{"not validatable", true},
+	}
+	for _, tt := range tests {
+		ctx := NewContext()
+		result := Validate(tt.value, ctx)
+		if result != tt.expected {
+			t.Errorf("Validate(%v) failed: got %v, expected %v", tt.value, result, tt.expected)
+		}
+	}
+}
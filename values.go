@@ -0,0 +1,324 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingValue = errors.New("missing value")
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+type ValueError struct {
+	Name  string
+	Value string
+	Err   error
+}
+
+func (e *ValueError) Error() string {
+	if errors.Is(e.Err, ErrMissingValue) {
+		return fmt.Sprintf("%s: missing value", e.Name)
+	}
+	return fmt.Sprintf("%s: invalid value %q", e.Name, e.Value)
+}
+
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+func (p RouteParams) Int(name string) (int, error) {
+	return parseInt(p.lookup(name))
+}
+
+func (p RouteParams) IntOr(name string, defaultValue int) (int, error) {
+	return withDefault(p.lookup(name), defaultValue, parseInt)
+}
+
+func (p RouteParams) Int64(name string) (int64, error) {
+	return parseInt64(p.lookup(name))
+}
+
+func (p RouteParams) Int64Or(name string, defaultValue int64) (int64, error) {
+	return withDefault(p.lookup(name), defaultValue, parseInt64)
+}
+
+func (p RouteParams) Bool(name string) (bool, error) {
+	return parseBool(p.lookup(name))
+}
+
+func (p RouteParams) BoolOr(name string, defaultValue bool) (bool, error) {
+	return withDefault(p.lookup(name), defaultValue, parseBool)
+}
+
+func (p RouteParams) UUID(name string) (string, error) {
+	return parseUUID(p.lookup(name))
+}
+
+func (p RouteParams) UUIDOr(name string, defaultValue string) (string, error) {
+	return withDefault(p.lookup(name), defaultValue, parseUUID)
+}
+
+func (p RouteParams) Time(name string, layout string) (time.Time, error) {
+	return parseTime(p.lookup(name), layout)
+}
+
+func (p RouteParams) TimeOr(name string, layout string, defaultValue time.Time) (time.Time, error) {
+	return withDefault(p.lookup(name), defaultValue, func(v lookupValue) (time.Time, error) {
+		return parseTime(v, layout)
+	})
+}
+
+func (p RouteParams) Duration(name string) (time.Duration, error) {
+	return parseDuration(p.lookup(name))
+}
+
+func (p RouteParams) DurationOr(name string, defaultValue time.Duration) (time.Duration, error) {
+	return withDefault(p.lookup(name), defaultValue, parseDuration)
+}
+
+func (p RouteParams) Enum(name string, allowed ...string) (string, error) {
+	return parseEnum(p.lookup(name), allowed)
+}
+
+func (p RouteParams) EnumOr(name string, defaultValue string, allowed ...string) (string, error) {
+	return withDefault(p.lookup(name), defaultValue, func(v lookupValue) (string, error) {
+		return parseEnum(v, allowed)
+	})
+}
+
+func (p RouteParams) List(name string) []string {
+	return splitList(p.lookup(name))
+}
+
+func (p RouteParams) IntList(name string) ([]int, error) {
+	return parseIntList(p.lookup(name))
+}
+
+func (p RouteParams) lookup(name string) lookupValue {
+	value, ok := p[strings.ToLower(name)]
+	return lookupValue{name: name, value: value, ok: ok}
+}
+
+func (q RouteQuery) Int(name string) (int, error) {
+	return parseInt(q.lookup(name))
+}
+
+func (q RouteQuery) IntOr(name string, defaultValue int) (int, error) {
+	return withDefault(q.lookup(name), defaultValue, parseInt)
+}
+
+func (q RouteQuery) Int64(name string) (int64, error) {
+	return parseInt64(q.lookup(name))
+}
+
+func (q RouteQuery) Int64Or(name string, defaultValue int64) (int64, error) {
+	return withDefault(q.lookup(name), defaultValue, parseInt64)
+}
+
+func (q RouteQuery) Bool(name string) (bool, error) {
+	return parseBool(q.lookup(name))
+}
+
+func (q RouteQuery) BoolOr(name string, defaultValue bool) (bool, error) {
+	return withDefault(q.lookup(name), defaultValue, parseBool)
+}
+
+func (q RouteQuery) UUID(name string) (string, error) {
+	return parseUUID(q.lookup(name))
+}
+
+func (q RouteQuery) UUIDOr(name string, defaultValue string) (string, error) {
+	return withDefault(q.lookup(name), defaultValue, parseUUID)
+}
+
+func (q RouteQuery) Time(name string, layout string) (time.Time, error) {
+	return parseTime(q.lookup(name), layout)
+}
+
+func (q RouteQuery) TimeOr(name string, layout string, defaultValue time.Time) (time.Time, error) {
+	return withDefault(q.lookup(name), defaultValue, func(v lookupValue) (time.Time, error) {
+		return parseTime(v, layout)
+	})
+}
+
+func (q RouteQuery) Duration(name string) (time.Duration, error) {
+	return parseDuration(q.lookup(name))
+}
+
+func (q RouteQuery) DurationOr(name string, defaultValue time.Duration) (time.Duration, error) {
+	return withDefault(q.lookup(name), defaultValue, parseDuration)
+}
+
+func (q RouteQuery) Enum(name string, allowed ...string) (string, error) {
+	return parseEnum(q.lookup(name), allowed)
+}
+
+func (q RouteQuery) EnumOr(name string, defaultValue string, allowed ...string) (string, error) {
+	return withDefault(q.lookup(name), defaultValue, func(v lookupValue) (string, error) {
+		return parseEnum(v, allowed)
+	})
+}
+
+func (q RouteQuery) List(name string) []string {
+	values := q[name]
+	return splitList(lookupValue{name: name, value: strings.Join(values, ","), ok: len(values) > 0})
+}
+
+func (q RouteQuery) IntList(name string) ([]int, error) {
+	values := q[name]
+	return parseIntList(lookupValue{name: name, value: strings.Join(values, ","), ok: len(values) > 0})
+}
+
+func (q RouteQuery) lookup(name string) lookupValue {
+	values := q[name]
+	if len(values) == 0 {
+		return lookupValue{name: name}
+	}
+	return lookupValue{name: name, value: values[0], ok: true}
+}
+
+type lookupValue struct {
+	name  string
+	value string
+	ok    bool
+}
+
+func (v lookupValue) missing() bool {
+	return !v.ok || v.value == ""
+}
+
+func (v lookupValue) newError(err error) error {
+	return &ValueError{Name: v.name, Value: v.value, Err: err}
+}
+
+func withDefault[T any](v lookupValue, defaultValue T, parse func(lookupValue) (T, error)) (T, error) {
+	if v.missing() {
+		return defaultValue, nil
+	}
+	result, err := parse(v)
+	if err != nil {
+		return defaultValue, err
+	}
+	return result, nil
+}
+
+func parseInt(v lookupValue) (int, error) {
+	if v.missing() {
+		return 0, v.newError(ErrMissingValue)
+	}
+	result, err := strconv.Atoi(v.value)
+	if err != nil {
+		return 0, v.newError(errors.Join(ErrInvalidValue, err))
+	}
+	return result, nil
+}
+
+func parseInt64(v lookupValue) (int64, error) {
+	if v.missing() {
+		return 0, v.newError(ErrMissingValue)
+	}
+	result, err := strconv.ParseInt(v.value, 10, 64)
+	if err != nil {
+		return 0, v.newError(errors.Join(ErrInvalidValue, err))
+	}
+	return result, nil
+}
+
+func parseBool(v lookupValue) (bool, error) {
+	if v.missing() {
+		return false, v.newError(ErrMissingValue)
+	}
+	result, err := strconv.ParseBool(v.value)
+	if err != nil {
+		return false, v.newError(errors.Join(ErrInvalidValue, err))
+	}
+	return result, nil
+}
+
+func parseUUID(v lookupValue) (string, error) {
+	if v.missing() {
+		return "", v.newError(ErrMissingValue)
+	}
+	if len(v.value) != 36 {
+		return "", v.newError(ErrInvalidValue)
+	}
+	for i, c := range v.value {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return "", v.newError(ErrInvalidValue)
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return "", v.newError(ErrInvalidValue)
+			}
+		}
+	}
+	return strings.ToLower(v.value), nil
+}
+
+func parseTime(v lookupValue, layout string) (time.Time, error) {
+	if v.missing() {
+		return time.Time{}, v.newError(ErrMissingValue)
+	}
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	result, err := time.Parse(layout, v.value)
+	if err != nil {
+		return time.Time{}, v.newError(errors.Join(ErrInvalidValue, err))
+	}
+	return result, nil
+}
+
+func parseDuration(v lookupValue) (time.Duration, error) {
+	if v.missing() {
+		return 0, v.newError(ErrMissingValue)
+	}
+	result, err := time.ParseDuration(v.value)
+	if err != nil {
+		return 0, v.newError(errors.Join(ErrInvalidValue, err))
+	}
+	return result, nil
+}
+
+func parseEnum(v lookupValue, allowed []string) (string, error) {
+	if v.missing() {
+		return "", v.newError(ErrMissingValue)
+	}
+	if !slices.Contains(allowed, v.value) {
+		return "", v.newError(ErrInvalidValue)
+	}
+	return v.value, nil
+}
+
+func splitList(v lookupValue) []string {
+	result := make([]string, 0)
+	if v.missing() {
+		return result
+	}
+	for _, part := range strings.Split(v.value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+func parseIntList(v lookupValue) ([]int, error) {
+	parts := splitList(v)
+	result := make([]int, 0, len(parts))
+	for _, part := range parts {
+		value, err := parseInt(lookupValue{name: v.name, value: part, ok: true})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
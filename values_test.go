@@ -0,0 +1,236 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func Test_RouteParams_Int(t *testing.T) {
+	params := RouteParams{"id": "42", "name": "abc", "empty": ""}
+	tests := []struct {
+		name     string
+		expected int
+		err      error
+	}{
+		{"id", 42, nil},
+		{"ID", 42, nil},
+		{"name", 0, ErrInvalidValue},
+		{"empty", 0, ErrMissingValue},
+		{"missing", 0, ErrMissingValue},
+	}
+	for _, tt := range tests {
+		result, err := params.Int(tt.name)
+		if result != tt.expected || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("RouteParams.Int(%s) failed: got %v/%v, expected %v/%v", tt.name, result, err, tt.expected, tt.err)
+		}
+	}
+}
+
+func Test_RouteParams_Int_Route(t *testing.T) {
+	var result int
+	var err error
+	router := NewRouter()
+	router.HandleFunc("/orders/{orderId}", func(w http.ResponseWriter, r *http.Request) {
+		result, err = Params(r).Int("orderId")
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/7", nil))
+
+	if result != 7 || err != nil {
+		t.Errorf("RouteParams.Int(orderId) failed: got %v/%v, expected %v/<nil>", result, err, 7)
+	}
+}
+
+func Test_RouteParams_IntOr(t *testing.T) {
+	params := RouteParams{"id": "42", "name": "abc"}
+	tests := []struct {
+		name     string
+		expected int
+		err      error
+	}{
+		{"id", 42, nil},
+		{"name", 7, ErrInvalidValue},
+		{"missing", 7, nil},
+	}
+	for _, tt := range tests {
+		result, err := params.IntOr(tt.name, 7)
+		if result != tt.expected || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("RouteParams.IntOr(%s) failed: got %v/%v, expected %v/%v", tt.name, result, err, tt.expected, tt.err)
+		}
+	}
+}
+
+func Test_RouteParams_Typed(t *testing.T) {
+	params := RouteParams{
+		"id":       "9007199254740993",
+		"active":   "true",
+		"uuid":     "6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"date":     "2024-03-04",
+		"interval": "1h30m",
+		"status":   "open",
+		"tags":     "a, b,,c",
+		"numbers":  "1,2,3",
+	}
+
+	if result, err := params.Int64("id"); err != nil || result != 9007199254740993 {
+		t.Errorf("RouteParams.Int64() failed: got %v/%v", result, err)
+	}
+	if result, err := params.Bool("active"); err != nil || !result {
+		t.Errorf("RouteParams.Bool() failed: got %v/%v", result, err)
+	}
+	if result, err := params.UUID("uuid"); err != nil || result != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("RouteParams.UUID() failed: got %v/%v", result, err)
+	}
+	if result, err := params.Time("date", "2006-01-02"); err != nil || !result.Equal(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("RouteParams.Time() failed: got %v/%v", result, err)
+	}
+	if result, err := params.Duration("interval"); err != nil || result != 90*time.Minute {
+		t.Errorf("RouteParams.Duration() failed: got %v/%v", result, err)
+	}
+	if result, err := params.Enum("status", "open", "closed"); err != nil || result != "open" {
+		t.Errorf("RouteParams.Enum() failed: got %v/%v", result, err)
+	}
+	if result := params.List("tags"); !slices.Equal(result, []string{"a", "b", "c"}) {
+		t.Errorf("RouteParams.List() failed: got %v, expected [a b c]", result)
+	}
+	if result, err := params.IntList("numbers"); err != nil || !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("RouteParams.IntList() failed: got %v/%v", result, err)
+	}
+}
+
+func Test_RouteParams_Typed_Invalid(t *testing.T) {
+	params := RouteParams{"value": "not-valid"}
+
+	if _, err := params.Int64("value"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RouteParams.Int64() failed: got %v, expected %v", err, ErrInvalidValue)
+	}
+	if _, err := params.Bool("value"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RouteParams.Bool() failed: got %v, expected %v", err, ErrInvalidValue)
+	}
+	if _, err := params.UUID("value"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RouteParams.UUID() failed: got %v, expected %v", err, ErrInvalidValue)
+	}
+	if _, err := params.Time("value", ""); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RouteParams.Time() failed: got %v, expected %v", err, ErrInvalidValue)
+	}
+	if _, err := params.Duration("value"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RouteParams.Duration() failed: got %v, expected %v", err, ErrInvalidValue)
+	}
+	if _, err := params.Enum("value", "open"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RouteParams.Enum() failed: got %v, expected %v", err, ErrInvalidValue)
+	}
+	if _, err := params.IntList("value"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RouteParams.IntList() failed: got %v, expected %v", err, ErrInvalidValue)
+	}
+}
+
+func Test_RouteParams_Defaults(t *testing.T) {
+	params := RouteParams{}
+	now := time.Now()
+
+	if result, err := params.Int64Or("id", 5); err != nil || result != 5 {
+		t.Errorf("RouteParams.Int64Or() failed: got %v/%v", result, err)
+	}
+	if result, err := params.BoolOr("active", true); err != nil || !result {
+		t.Errorf("RouteParams.BoolOr() failed: got %v/%v", result, err)
+	}
+	if result, err := params.UUIDOr("uuid", "default"); err != nil || result != "default" {
+		t.Errorf("RouteParams.UUIDOr() failed: got %v/%v", result, err)
+	}
+	if result, err := params.TimeOr("date", "", now); err != nil || !result.Equal(now) {
+		t.Errorf("RouteParams.TimeOr() failed: got %v/%v", result, err)
+	}
+	if result, err := params.DurationOr("interval", time.Minute); err != nil || result != time.Minute {
+		t.Errorf("RouteParams.DurationOr() failed: got %v/%v", result, err)
+	}
+	if result, err := params.EnumOr("status", "open", "open", "closed"); err != nil || result != "open" {
+		t.Errorf("RouteParams.EnumOr() failed: got %v/%v", result, err)
+	}
+	if result := params.List("tags"); len(result) != 0 {
+		t.Errorf("RouteParams.List() failed: got %v, expected empty", result)
+	}
+}
+
+func Test_RouteQuery_Typed(t *testing.T) {
+	query := RouteQuery{
+		"page":     {"2", "3"},
+		"id":       {"12"},
+		"active":   {"false"},
+		"uuid":     {"6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		"since":    {"2024-03-04T10:00:00Z"},
+		"timeout":  {"5s"},
+		"sort":     {"asc"},
+		"status":   {"open,closed", "pending"},
+		"ids":      {"1,2", "3"},
+		"empty":    {},
+		"invalid":  {"x"},
+		"language": {"nl"},
+	}
+
+	if result, err := query.Int("page"); err != nil || result != 2 {
+		t.Errorf("RouteQuery.Int() failed: got %v/%v", result, err)
+	}
+	if result, err := query.IntOr("empty", 1); err != nil || result != 1 {
+		t.Errorf("RouteQuery.IntOr() failed: got %v/%v", result, err)
+	}
+	if result, err := query.Int64("id"); err != nil || result != 12 {
+		t.Errorf("RouteQuery.Int64() failed: got %v/%v", result, err)
+	}
+	if result, err := query.Int64Or("invalid", 1); !errors.Is(err, ErrInvalidValue) || result != 1 {
+		t.Errorf("RouteQuery.Int64Or() failed: got %v/%v", result, err)
+	}
+	if result, err := query.Bool("active"); err != nil || result {
+		t.Errorf("RouteQuery.Bool() failed: got %v/%v", result, err)
+	}
+	if result, err := query.BoolOr("missing", true); err != nil || !result {
+		t.Errorf("RouteQuery.BoolOr() failed: got %v/%v", result, err)
+	}
+	if result, err := query.UUID("uuid"); err != nil || result != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("RouteQuery.UUID() failed: got %v/%v", result, err)
+	}
+	if _, err := query.UUIDOr("invalid", ""); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("RouteQuery.UUIDOr() failed: got %v, expected %v", err, ErrInvalidValue)
+	}
+	if result, err := query.Time("since", ""); err != nil || result.Hour() != 10 {
+		t.Errorf("RouteQuery.Time() failed: got %v/%v", result, err)
+	}
+	if _, err := query.TimeOr("missing", "", time.Time{}); err != nil {
+		t.Errorf("RouteQuery.TimeOr() failed: got %v", err)
+	}
+	if result, err := query.Duration("timeout"); err != nil || result != 5*time.Second {
+		t.Errorf("RouteQuery.Duration() failed: got %v/%v", result, err)
+	}
+	if result, err := query.DurationOr("missing", time.Second); err != nil || result != time.Second {
+		t.Errorf("RouteQuery.DurationOr() failed: got %v/%v", result, err)
+	}
+	if result, err := query.Enum("sort", "asc", "desc"); err != nil || result != "asc" {
+		t.Errorf("RouteQuery.Enum() failed: got %v/%v", result, err)
+	}
+	if result, err := query.EnumOr("language", "en", "en", "fr"); !errors.Is(err, ErrInvalidValue) || result != "en" {
+		t.Errorf("RouteQuery.EnumOr() failed: got %v/%v", result, err)
+	}
+	if result := query.List("status"); !slices.Equal(result, []string{"open", "closed", "pending"}) {
+		t.Errorf("RouteQuery.List() failed: got %v, expected [open closed pending]", result)
+	}
+	if result, err := query.IntList("ids"); err != nil || !slices.Equal(result, []int{1, 2, 3}) {
+		t.Errorf("RouteQuery.IntList() failed: got %v/%v", result, err)
+	}
+}
+
+func Test_ValueError_Error(t *testing.T) {
+	tests := []struct {
+		err      *ValueError
+		expected string
+	}{
+		{&ValueError{Name: "id", Err: ErrMissingValue}, "id: missing value"},
+		{&ValueError{Name: "id", Value: "abc", Err: ErrInvalidValue}, "id: invalid value \"abc\""},
+	}
+	for _, tt := range tests {
+		if result := tt.err.Error(); result != tt.expected {
+			t.Errorf("ValueError.Error() failed: got %s, expected %s", result, tt.expected)
+		}
+	}
+}
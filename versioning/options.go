@@ -0,0 +1,28 @@
+package versioning
+
+func WithStrategy(strategy Strategy) VersionerOption {
+	return func(v *Versioner) {
+		v.Strategy = strategy
+	}
+}
+
+func WithDefaultVersion(version string) VersionerOption {
+	return func(v *Versioner) {
+		v.DefaultVersion = version
+	}
+}
+
+func WithVersion(name string, opts ...VersionOption) VersionerOption {
+	return func(v *Versioner) {
+		if v.versions == nil {
+			v.versions = make(map[string]*Version)
+		}
+		version := &Version{
+			Name: name,
+		}
+		for _, opt := range opts {
+			opt(version)
+		}
+		v.versions[name] = version
+	}
+}
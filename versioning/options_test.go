@@ -0,0 +1,43 @@
+package versioning
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_WithStrategy(t *testing.T) {
+	expected := HeaderStrategy("X-Version")
+	v := &Versioner{}
+	option := WithStrategy(expected)
+	option(v)
+
+	if v.Strategy != expected {
+		t.Errorf("WithStrategy() failed: got %v, expected %v", v.Strategy, expected)
+	}
+}
+
+func Test_WithDefaultVersion(t *testing.T) {
+	expected := "2"
+	v := &Versioner{}
+	option := WithDefaultVersion(expected)
+	option(v)
+
+	if v.DefaultVersion != expected {
+		t.Errorf("WithDefaultVersion() failed: got %s, expected %s", v.DefaultVersion, expected)
+	}
+}
+
+func Test_WithVersion(t *testing.T) {
+	deprecated := time.Unix(1700000000, 0)
+	v := &Versioner{}
+	option := WithVersion("1", Deprecated(deprecated))
+	option(v)
+
+	version, ok := v.GetVersion("1")
+	if !ok {
+		t.Fatal("WithVersion() failed: version not registered")
+	}
+	if !version.Deprecated.Equal(deprecated) {
+		t.Errorf("WithVersion() failed: got deprecated %v, expected %v", version.Deprecated, deprecated)
+	}
+}
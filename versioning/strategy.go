@@ -0,0 +1,112 @@
+package versioning
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	DefaultHeaderName   string = "X-API-Version"
+	DefaultParamName    string = "version"
+	DefaultPrefixFormat string = "/v%s"
+)
+
+type Strategy interface {
+	Pattern(pattern string, version string) string
+	Version(r *http.Request) (string, bool)
+	Vary() string
+}
+
+type prefixStrategy struct {
+	format string
+}
+
+func PrefixStrategy(format string) Strategy {
+	if format == "" {
+		format = DefaultPrefixFormat
+	}
+	return &prefixStrategy{
+		format: format,
+	}
+}
+
+func (s *prefixStrategy) Pattern(pattern string, version string) string {
+	qualifier, path := "", pattern
+	if i := strings.Index(pattern, "/"); i > 0 && strings.ContainsAny(pattern[:i], " \t.") {
+		qualifier, path = pattern[:i], pattern[i:]
+	}
+	prefix := strings.TrimSuffix(fmt.Sprintf(s.format, version), "/")
+	return qualifier + prefix + "/" + strings.TrimPrefix(path, "/")
+}
+
+func (s *prefixStrategy) Version(r *http.Request) (string, bool) {
+	return "", false
+}
+
+func (s *prefixStrategy) Vary() string {
+	return ""
+}
+
+type headerStrategy struct {
+	name string
+}
+
+func HeaderStrategy(name string) Strategy {
+	if name == "" {
+		name = DefaultHeaderName
+	}
+	return &headerStrategy{
+		name: name,
+	}
+}
+
+func (s *headerStrategy) Pattern(pattern string, version string) string {
+	return pattern
+}
+
+func (s *headerStrategy) Version(r *http.Request) (string, bool) {
+	version := strings.TrimSpace(r.Header.Get(s.name))
+	return version, version != ""
+}
+
+func (s *headerStrategy) Vary() string {
+	return s.name
+}
+
+type acceptStrategy struct {
+	param string
+}
+
+func AcceptStrategy(param string) Strategy {
+	if param == "" {
+		param = DefaultParamName
+	}
+	return &acceptStrategy{
+		param: param,
+	}
+}
+
+func (s *acceptStrategy) Pattern(pattern string, version string) string {
+	return pattern
+}
+
+func (s *acceptStrategy) Version(r *http.Request) (string, bool) {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			_, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+			if version := params[s.param]; version != "" {
+				return version, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (s *acceptStrategy) Vary() string {
+	return "Accept"
+}
@@ -0,0 +1,83 @@
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_PrefixStrategy(t *testing.T) {
+	tests := []struct {
+		format   string
+		pattern  string
+		version  string
+		expected string
+	}{
+		{"", "/orders", "1", "/v1/orders"},
+		{"/api/v%s/", "orders/{id}", "2", "/api/v2/orders/{id}"},
+		{"", "GET /orders", "1", "GET /v1/orders"},
+		{"", "GET api.example.com/orders", "1", "GET api.example.com/v1/orders"},
+	}
+	for _, tt := range tests {
+		s := PrefixStrategy(tt.format)
+		result := s.Pattern(tt.pattern, tt.version)
+		if result != tt.expected {
+			t.Errorf("PrefixStrategy(%s).Pattern(%s) failed: got %s, expected %s", tt.format, tt.pattern, result, tt.expected)
+		}
+	}
+
+	s := PrefixStrategy("")
+	if _, ok := s.Version(httptest.NewRequest(http.MethodGet, "/v1/orders", nil)); ok {
+		t.Error("PrefixStrategy.Version() failed: got true, expected false")
+	}
+	if s.Vary() != "" {
+		t.Errorf("PrefixStrategy.Vary() failed: got %s, expected <empty>", s.Vary())
+	}
+}
+
+func Test_HeaderStrategy(t *testing.T) {
+	s := HeaderStrategy("")
+	if s.Pattern("/orders", "1") != "/orders" {
+		t.Errorf("HeaderStrategy.Pattern() failed: got %s, expected %s", s.Pattern("/orders", "1"), "/orders")
+	}
+	if s.Vary() != DefaultHeaderName {
+		t.Errorf("HeaderStrategy.Vary() failed: got %s, expected %s", s.Vary(), DefaultHeaderName)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	if _, ok := s.Version(req); ok {
+		t.Error("HeaderStrategy.Version(missing) failed: got true, expected false")
+	}
+	req.Header.Set(DefaultHeaderName, " 2 ")
+	if version, ok := s.Version(req); !ok || version != "2" {
+		t.Errorf("HeaderStrategy.Version() failed: got %s, expected %s", version, "2")
+	}
+}
+
+func Test_AcceptStrategy(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected string
+		ok       bool
+	}{
+		{"", "", false},
+		{"application/json", "", false},
+		{"application/vnd.x+json;version=2", "2", true},
+		{"text/html, application/vnd.x+json; version=3; q=0.9", "3", true},
+		{"invalid;;, application/json;version=1", "1", true},
+	}
+	s := AcceptStrategy("")
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		version, ok := s.Version(req)
+		if ok != tt.ok || version != tt.expected {
+			t.Errorf("AcceptStrategy.Version(%s) failed: got %s/%v, expected %s/%v", tt.accept, version, ok, tt.expected, tt.ok)
+		}
+	}
+	if s.Vary() != "Accept" {
+		t.Errorf("AcceptStrategy.Vary() failed: got %s, expected %s", s.Vary(), "Accept")
+	}
+}
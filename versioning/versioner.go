@@ -0,0 +1,93 @@
+package versioning
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/deb-ict/go-router"
+)
+
+type VersionerOption func(*Versioner)
+
+type Versioner struct {
+	Strategy       Strategy
+	DefaultVersion string
+	versions       map[string]*Version
+}
+
+func NewVersioner(opts ...VersionerOption) *Versioner {
+	v := &Versioner{
+		versions: make(map[string]*Version),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	v.EnsureDefaults()
+
+	return v
+}
+
+func (v *Versioner) EnsureDefaults() {
+	if v.Strategy == nil {
+		v.Strategy = PrefixStrategy(DefaultPrefixFormat)
+	}
+	if v.versions == nil {
+		v.versions = make(map[string]*Version)
+	}
+}
+
+func (v *Versioner) GetVersion(name string) (*Version, bool) {
+	version, ok := v.versions[name]
+	return version, ok
+}
+
+func (v *Versioner) HandleFunc(r *router.Router, pattern string, version string, handle http.HandlerFunc, opts ...router.RouteOption) *router.Route {
+	return v.Handle(r, pattern, version, http.HandlerFunc(handle), opts...)
+}
+
+func (v *Versioner) Handle(r *router.Router, pattern string, version string, handler http.Handler, opts ...router.RouteOption) *router.Route {
+	versioned := v.Strategy.Pattern(pattern, version)
+	routeOpts := []router.RouteOption{
+		router.Metadata(metadataKey, version),
+	}
+	if versioned == pattern {
+		// The version is not part of the path, so the request has to be matched on it
+		routeOpts = append(routeOpts, router.Matcher(v.matcher(version)))
+	} else if v.DefaultVersion != "" && strings.EqualFold(v.DefaultVersion, version) {
+		// Requests without the version prefix are served by the default version
+		r.Handle(pattern, v.handler(version, handler), append(routeOpts, opts...)...)
+	}
+	return r.Handle(versioned, v.handler(version, handler), append(routeOpts, opts...)...)
+}
+
+func (v *Versioner) RequestedVersion(r *http.Request) string {
+	if version, ok := v.Strategy.Version(r); ok {
+		return version
+	}
+	return v.DefaultVersion
+}
+
+func (v *Versioner) matcher(version string) router.RouteMatcher {
+	return func(r *http.Request) bool {
+		requested, ok := v.Strategy.Version(r)
+		if !ok {
+			if v.DefaultVersion == "" {
+				return true
+			}
+			requested = v.DefaultVersion
+		}
+		return strings.EqualFold(requested, version)
+	}
+}
+
+func (v *Versioner) handler(version string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if vary := v.Strategy.Vary(); vary != "" {
+			w.Header().Add("Vary", vary)
+		}
+		if info, ok := v.versions[version]; ok {
+			info.WriteHeaders(w.Header())
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,170 @@
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func versionHandler(version string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(version))
+	}
+}
+
+func Test_NewVersioner(t *testing.T) {
+	v := NewVersioner()
+
+	if v.Strategy == nil {
+		t.Error("NewVersioner() failed: default strategy not set")
+	}
+	if v.versions == nil {
+		t.Error("NewVersioner() failed: versions not initialized")
+	}
+}
+
+func Test_Versioner_Prefix(t *testing.T) {
+	v := NewVersioner(WithVersion("1", Deprecated(time.Unix(1700000000, 0))))
+	r := router.NewRouter()
+	v.HandleFunc(r, "/orders", "1", versionHandler("v1"))
+	v.HandleFunc(r, "/orders", "2", versionHandler("v2"))
+
+	tests := []struct {
+		path        string
+		status      int
+		expected    string
+		deprecation string
+	}{
+		{"/v1/orders", http.StatusOK, "v1", "@1700000000"},
+		{"/v2/orders", http.StatusOK, "v2", ""},
+		{"/orders", http.StatusNotFound, "", ""},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("ServeHTTP(%s) failed: got status %v, expected %v", tt.path, rec.Code, tt.status)
+		}
+		if tt.expected != "" && rec.Body.String() != tt.expected {
+			t.Errorf("ServeHTTP(%s) failed: got %s, expected %s", tt.path, rec.Body.String(), tt.expected)
+		}
+		if rec.Header().Get("Deprecation") != tt.deprecation {
+			t.Errorf("ServeHTTP(%s) failed: got deprecation %s, expected %s", tt.path, rec.Header().Get("Deprecation"), tt.deprecation)
+		}
+	}
+}
+
+func Test_Versioner_Prefix_DefaultVersion(t *testing.T) {
+	v := NewVersioner(WithDefaultVersion("1"))
+	r := router.NewRouter()
+	v.HandleFunc(r, "/items", "1", versionHandler("v1"))
+	v.HandleFunc(r, "/items", "2", versionHandler("v2"))
+
+	tests := []struct {
+		path     string
+		status   int
+		expected string
+	}{
+		{"/v1/items", http.StatusOK, "v1"},
+		{"/v2/items", http.StatusOK, "v2"},
+		{"/items", http.StatusOK, "v1"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("ServeHTTP(%s) failed: got status %v, expected %v", tt.path, rec.Code, tt.status)
+		}
+		if tt.expected != "" && rec.Body.String() != tt.expected {
+			t.Errorf("ServeHTTP(%s) failed: got %s, expected %s", tt.path, rec.Body.String(), tt.expected)
+		}
+	}
+}
+
+func Test_Versioner_Header(t *testing.T) {
+	v := NewVersioner(
+		WithStrategy(HeaderStrategy("")),
+		WithDefaultVersion("2"),
+		WithVersion("1", Sunset(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))),
+	)
+	r := router.NewRouter()
+	v.HandleFunc(r, "/orders", "1", versionHandler("v1")).AllowedMethod(http.MethodGet)
+	v.HandleFunc(r, "/orders", "2", versionHandler("v2")).AllowedMethod(http.MethodGet)
+
+	tests := []struct {
+		version  string
+		status   int
+		expected string
+		sunset   bool
+	}{
+		{"1", http.StatusOK, "v1", true},
+		{"2", http.StatusOK, "v2", false},
+		{"", http.StatusOK, "v2", false},
+		{"9", http.StatusNotFound, "", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		if tt.version != "" {
+			req.Header.Set(DefaultHeaderName, tt.version)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("ServeHTTP(%s) failed: got status %v, expected %v", tt.version, rec.Code, tt.status)
+		}
+		if tt.expected != "" && rec.Body.String() != tt.expected {
+			t.Errorf("ServeHTTP(%s) failed: got %s, expected %s", tt.version, rec.Body.String(), tt.expected)
+		}
+		if tt.status == http.StatusOK && rec.Header().Get("Vary") != DefaultHeaderName {
+			t.Errorf("ServeHTTP(%s) failed: got vary %s, expected %s", tt.version, rec.Header().Get("Vary"), DefaultHeaderName)
+		}
+		if (rec.Header().Get("Sunset") != "") != tt.sunset {
+			t.Errorf("ServeHTTP(%s) failed: got sunset %s", tt.version, rec.Header().Get("Sunset"))
+		}
+	}
+}
+
+func Test_Versioner_Accept(t *testing.T) {
+	v := NewVersioner(WithStrategy(AcceptStrategy("")))
+	r := router.NewRouter()
+	v.HandleFunc(r, "/orders", "1", versionHandler("v1"))
+	v.HandleFunc(r, "/orders", "2", versionHandler("v2"))
+
+	tests := []struct {
+		accept   string
+		expected string
+	}{
+		{"application/vnd.x+json;version=2", "v2"},
+		{"application/vnd.x+json;version=1", "v1"},
+		{"application/json", "v1"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set("Accept", tt.accept)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Body.String() != tt.expected {
+			t.Errorf("ServeHTTP(%s) failed: got %s, expected %s", tt.accept, rec.Body.String(), tt.expected)
+		}
+	}
+}
+
+func Test_Versioner_RequestedVersion(t *testing.T) {
+	v := NewVersioner(WithStrategy(HeaderStrategy("")), WithDefaultVersion("2"))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	if result := v.RequestedVersion(req); result != "2" {
+		t.Errorf("RequestedVersion(default) failed: got %s, expected %s", result, "2")
+	}
+	req.Header.Set(DefaultHeaderName, "1")
+	if result := v.RequestedVersion(req); result != "1" {
+		t.Errorf("RequestedVersion() failed: got %s, expected %s", result, "1")
+	}
+}
@@ -0,0 +1,77 @@
+package versioning
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+const (
+	metadataKey router.MetadataKey = "router::version"
+)
+
+type VersionOption func(*Version)
+
+type Version struct {
+	Name            string
+	Deprecated      time.Time
+	Sunset          time.Time
+	DeprecationLink string
+}
+
+func Deprecated(at time.Time) VersionOption {
+	return func(v *Version) {
+		v.Deprecated = at
+	}
+}
+
+func Sunset(at time.Time) VersionOption {
+	return func(v *Version) {
+		v.Sunset = at
+	}
+}
+
+func DeprecationLink(url string) VersionOption {
+	return func(v *Version) {
+		v.DeprecationLink = url
+	}
+}
+
+func (v *Version) IsDeprecated() bool {
+	return !v.Deprecated.IsZero()
+}
+
+func (v *Version) WriteHeaders(header http.Header) {
+	if v.IsDeprecated() {
+		header.Set("Deprecation", "@"+formatUnix(v.Deprecated))
+	}
+	if !v.Sunset.IsZero() {
+		header.Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if v.DeprecationLink != "" {
+		header.Add("Link", "<"+v.DeprecationLink+">; rel=\"deprecation\"")
+	}
+}
+
+func GetRouteVersion(route *router.Route) (string, bool) {
+	if route == nil {
+		return "", false
+	}
+	value, ok := route.GetMetadata(metadataKey)
+	if !ok {
+		return "", false
+	}
+	version, ok := value.(string)
+	return version, ok
+}
+
+func CurrentVersion(r *http.Request) string {
+	version, _ := GetRouteVersion(router.CurrentRoute(r))
+	return version
+}
+
+func formatUnix(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
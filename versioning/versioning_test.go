@@ -0,0 +1,74 @@
+package versioning
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deb-ict/go-router"
+)
+
+func Test_Version_WriteHeaders(t *testing.T) {
+	deprecated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := &Version{Name: "1"}
+	Deprecated(deprecated)(v)
+	Sunset(sunset)(v)
+	DeprecationLink("https://example.com/migrate")(v)
+
+	header := http.Header{}
+	v.WriteHeaders(header)
+
+	if header.Get("Deprecation") != "@1704067200" {
+		t.Errorf("WriteHeaders() failed: got deprecation %s, expected %s", header.Get("Deprecation"), "@1704067200")
+	}
+	if header.Get("Sunset") != "Wed, 01 Jan 2025 00:00:00 GMT" {
+		t.Errorf("WriteHeaders() failed: got sunset %s, expected %s", header.Get("Sunset"), "Wed, 01 Jan 2025 00:00:00 GMT")
+	}
+	if header.Get("Link") != "<https://example.com/migrate>; rel=\"deprecation\"" {
+		t.Errorf("WriteHeaders() failed: got link %s", header.Get("Link"))
+	}
+}
+
+func Test_Version_WriteHeaders_Current(t *testing.T) {
+	v := &Version{Name: "2"}
+	header := http.Header{}
+	v.WriteHeaders(header)
+
+	if v.IsDeprecated() {
+		t.Error("IsDeprecated() failed: got true, expected false")
+	}
+	if len(header) != 0 {
+		t.Errorf("WriteHeaders() failed: got %v, expected no headers", header)
+	}
+}
+
+func Test_GetRouteVersion(t *testing.T) {
+	if _, ok := GetRouteVersion(nil); ok {
+		t.Error("GetRouteVersion(nil) failed: got true, expected false")
+	}
+	if _, ok := GetRouteVersion(&router.Route{}); ok {
+		t.Error("GetRouteVersion(empty) failed: got true, expected false")
+	}
+
+	route := &router.Route{}
+	route.SetMetadata(metadataKey, "2")
+	if version, ok := GetRouteVersion(route); !ok || version != "2" {
+		t.Errorf("GetRouteVersion() failed: got %s, expected %s", version, "2")
+	}
+}
+
+func Test_CurrentVersion(t *testing.T) {
+	v := NewVersioner(WithStrategy(HeaderStrategy("")))
+	r := router.NewRouter()
+	result := ""
+	v.HandleFunc(r, "/orders", "3", func(w http.ResponseWriter, r *http.Request) {
+		result = CurrentVersion(r)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if result != "3" {
+		t.Errorf("CurrentVersion() failed: got %s, expected %s", result, "3")
+	}
+}